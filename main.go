@@ -12,10 +12,12 @@ var version = "0.6.0"
 func main() {
 	project.Version = version
 	plugin.Serve(&plugin.ServeOpts{
-		RuleSet: &tflint.BuiltinRuleSet{
-			Name:    "azurerm-ext",
-			Version: project.Version,
-			Rules:   rules.Rules,
+		RuleSet: &rules.RuleSet{
+			BuiltinRuleSet: tflint.BuiltinRuleSet{
+				Name:    "azurerm-ext",
+				Version: project.Version,
+				Rules:   rules.Rules,
+			},
 		},
 	})
 }
@@ -65,9 +65,11 @@ func mergeRange(sections ...Section) *hcl.Range {
 
 // Arg is a wrapper of the attribute
 type Arg struct {
-	Name  string
-	Range hcl.Range
-	File  *hcl.File
+	Name      string
+	Range     hcl.Range
+	NameRange hcl.Range
+	File      *hcl.File
+	Expr      hclsyntax.Expression
 }
 
 // ToString prints the arg content
@@ -211,8 +213,10 @@ func (a *HeadMetaArgs) updateRange(arg *Arg) {
 
 func buildAttrArg(attr *hclsyntax.Attribute, file *hcl.File) *Arg {
 	return &Arg{
-		Name:  attr.Name,
-		Range: attr.SrcRange,
-		File:  file,
+		Name:      attr.Name,
+		Range:     attr.SrcRange,
+		NameRange: attr.NameRange,
+		File:      file,
+		Expr:      attr.Expr,
 	}
 }
@@ -0,0 +1,149 @@
+package rules
+
+import "strings"
+
+// armResourceTypes are the ARM resource types of the azurerm resources and data sources whose `id` is an ARM resource ID
+var armResourceTypes = map[string]string{
+	"azurerm_api_management":                         "Microsoft.ApiManagement/service",
+	"azurerm_app_configuration":                      "Microsoft.AppConfiguration/configurationStores",
+	"azurerm_application_gateway":                    "Microsoft.Network/applicationGateways",
+	"azurerm_application_insights":                   "Microsoft.Insights/components",
+	"azurerm_application_security_group":             "Microsoft.Network/applicationSecurityGroups",
+	"azurerm_automation_account":                     "Microsoft.Automation/automationAccounts",
+	"azurerm_availability_set":                       "Microsoft.Compute/availabilitySets",
+	"azurerm_bastion_host":                           "Microsoft.Network/bastionHosts",
+	"azurerm_cognitive_account":                      "Microsoft.CognitiveServices/accounts",
+	"azurerm_container_app":                          "Microsoft.App/containerApps",
+	"azurerm_container_app_environment":              "Microsoft.App/managedEnvironments",
+	"azurerm_container_registry":                     "Microsoft.ContainerRegistry/registries",
+	"azurerm_cosmosdb_account":                       "Microsoft.DocumentDB/databaseAccounts",
+	"azurerm_data_factory":                           "Microsoft.DataFactory/factories",
+	"azurerm_databricks_workspace":                   "Microsoft.Databricks/workspaces",
+	"azurerm_disk_encryption_set":                    "Microsoft.Compute/diskEncryptionSets",
+	"azurerm_dns_zone":                               "Microsoft.Network/dnsZones",
+	"azurerm_eventgrid_topic":                        "Microsoft.EventGrid/topics",
+	"azurerm_eventhub":                               "Microsoft.EventHub/namespaces/eventhubs",
+	"azurerm_eventhub_namespace":                     "Microsoft.EventHub/namespaces",
+	"azurerm_firewall":                               "Microsoft.Network/azureFirewalls",
+	"azurerm_firewall_policy":                        "Microsoft.Network/firewallPolicies",
+	"azurerm_key_vault":                              "Microsoft.KeyVault/vaults",
+	"azurerm_kubernetes_cluster":                     "Microsoft.ContainerService/managedClusters",
+	"azurerm_kubernetes_cluster_node_pool":           "Microsoft.ContainerService/managedClusters/agentPools",
+	"azurerm_lb":                                     "Microsoft.Network/loadBalancers",
+	"azurerm_lb_backend_address_pool":                "Microsoft.Network/loadBalancers/backendAddressPools",
+	"azurerm_linux_function_app":                     "Microsoft.Web/sites",
+	"azurerm_linux_virtual_machine":                  "Microsoft.Compute/virtualMachines",
+	"azurerm_linux_virtual_machine_scale_set":        "Microsoft.Compute/virtualMachineScaleSets",
+	"azurerm_linux_web_app":                          "Microsoft.Web/sites",
+	"azurerm_log_analytics_workspace":                "Microsoft.OperationalInsights/workspaces",
+	"azurerm_machine_learning_workspace":             "Microsoft.MachineLearningServices/workspaces",
+	"azurerm_managed_disk":                           "Microsoft.Compute/disks",
+	"azurerm_monitor_action_group":                   "Microsoft.Insights/actionGroups",
+	"azurerm_mssql_database":                         "Microsoft.Sql/servers/databases",
+	"azurerm_mssql_elasticpool":                      "Microsoft.Sql/servers/elasticPools",
+	"azurerm_mssql_server":                           "Microsoft.Sql/servers",
+	"azurerm_mysql_flexible_server":                  "Microsoft.DBforMySQL/flexibleServers",
+	"azurerm_nat_gateway":                            "Microsoft.Network/natGateways",
+	"azurerm_network_ddos_protection_plan":           "Microsoft.Network/ddosProtectionPlans",
+	"azurerm_network_interface":                      "Microsoft.Network/networkInterfaces",
+	"azurerm_network_security_group":                 "Microsoft.Network/networkSecurityGroups",
+	"azurerm_orchestrated_virtual_machine_scale_set": "Microsoft.Compute/virtualMachineScaleSets",
+	"azurerm_postgresql_flexible_server":             "Microsoft.DBforPostgreSQL/flexibleServers",
+	"azurerm_private_dns_zone":                       "Microsoft.Network/privateDnsZones",
+	"azurerm_private_dns_zone_virtual_network_link":  "Microsoft.Network/privateDnsZones/virtualNetworkLinks",
+	"azurerm_private_endpoint":                       "Microsoft.Network/privateEndpoints",
+	"azurerm_proximity_placement_group":              "Microsoft.Compute/proximityPlacementGroups",
+	"azurerm_public_ip":                              "Microsoft.Network/publicIPAddresses",
+	"azurerm_public_ip_prefix":                       "Microsoft.Network/publicIPPrefixes",
+	"azurerm_recovery_services_vault":                "Microsoft.RecoveryServices/vaults",
+	"azurerm_redis_cache":                            "Microsoft.Cache/redis",
+	"azurerm_resource_group":                         resourceGroupARMType,
+	"azurerm_route_table":                            "Microsoft.Network/routeTables",
+	"azurerm_search_service":                         "Microsoft.Search/searchServices",
+	"azurerm_service_plan":                           "Microsoft.Web/serverFarms",
+	"azurerm_servicebus_namespace":                   "Microsoft.ServiceBus/namespaces",
+	"azurerm_servicebus_queue":                       "Microsoft.ServiceBus/namespaces/queues",
+	"azurerm_servicebus_topic":                       "Microsoft.ServiceBus/namespaces/topics",
+	"azurerm_shared_image":                           "Microsoft.Compute/galleries/images",
+	"azurerm_shared_image_gallery":                   "Microsoft.Compute/galleries",
+	"azurerm_storage_account":                        "Microsoft.Storage/storageAccounts",
+	"azurerm_subnet":                                 "Microsoft.Network/virtualNetworks/subnets",
+	"azurerm_synapse_workspace":                      "Microsoft.Synapse/workspaces",
+	"azurerm_user_assigned_identity":                 "Microsoft.ManagedIdentity/userAssignedIdentities",
+	"azurerm_virtual_hub":                            "Microsoft.Network/virtualHubs",
+	"azurerm_virtual_machine":                        "Microsoft.Compute/virtualMachines",
+	"azurerm_virtual_network":                        "Microsoft.Network/virtualNetworks",
+	"azurerm_virtual_network_gateway":                "Microsoft.Network/virtualNetworkGateways",
+	"azurerm_virtual_wan":                            "Microsoft.Network/virtualWans",
+	"azurerm_windows_function_app":                   "Microsoft.Web/sites",
+	"azurerm_windows_virtual_machine":                "Microsoft.Compute/virtualMachines",
+	"azurerm_windows_virtual_machine_scale_set":      "Microsoft.Compute/virtualMachineScaleSets",
+	"azurerm_windows_web_app":                        "Microsoft.Web/sites",
+}
+
+// sameARMType compares ARM resource types, which are case-insensitive
+func sameARMType(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
+
+// resourceIDArguments are the ARM resource types expected by the arguments holding resource IDs, keyed by the argument name,
+// for the resources and data sources which don't override them in resourceIDArgumentPaths
+var resourceIDArguments = map[string]string{
+	"action_group_id":                "Microsoft.Insights/actionGroups",
+	"application_insights_id":        "Microsoft.Insights/components",
+	"application_security_group_ids": "Microsoft.Network/applicationSecurityGroups",
+	"availability_set_id":            "Microsoft.Compute/availabilitySets",
+	"container_app_environment_id":   "Microsoft.App/managedEnvironments",
+	"container_registry_id":          "Microsoft.ContainerRegistry/registries",
+	"disk_encryption_set_id":         "Microsoft.Compute/diskEncryptionSets",
+	"firewall_policy_id":             "Microsoft.Network/firewallPolicies",
+	"identity_ids":                   "Microsoft.ManagedIdentity/userAssignedIdentities",
+	"key_vault_id":                   "Microsoft.KeyVault/vaults",
+	"kubernetes_cluster_id":          "Microsoft.ContainerService/managedClusters",
+	"log_analytics_workspace_id":     "Microsoft.OperationalInsights/workspaces",
+	"managed_disk_id":                "Microsoft.Compute/disks",
+	"nat_gateway_id":                 "Microsoft.Network/natGateways",
+	"network_interface_id":           "Microsoft.Network/networkInterfaces",
+	"network_interface_ids":          "Microsoft.Network/networkInterfaces",
+	"network_security_group_id":      "Microsoft.Network/networkSecurityGroups",
+	"private_dns_zone_ids":           "Microsoft.Network/privateDnsZones",
+	"proximity_placement_group_id":   "Microsoft.Compute/proximityPlacementGroups",
+	"public_ip_address_id":           "Microsoft.Network/publicIPAddresses",
+	"public_ip_prefix_id":            "Microsoft.Network/publicIPPrefixes",
+	"resource_group_id":              resourceGroupARMType,
+	"route_table_id":                 "Microsoft.Network/routeTables",
+	"service_plan_id":                "Microsoft.Web/serverFarms",
+	"storage_account_id":             "Microsoft.Storage/storageAccounts",
+	"subnet_id":                      "Microsoft.Network/virtualNetworks/subnets",
+	"user_assigned_identity_id":      "Microsoft.ManagedIdentity/userAssignedIdentities",
+	"virtual_hub_id":                 "Microsoft.Network/virtualHubs",
+	"virtual_machine_id":             "Microsoft.Compute/virtualMachines",
+	"virtual_network_id":             "Microsoft.Network/virtualNetworks",
+	"virtual_wan_id":                 "Microsoft.Network/virtualWans",
+}
+
+// resourceIDArgumentPaths are the ARM resource types expected by the arguments holding resource IDs, keyed by the
+// resource type and the path of the argument, e.g. `azurerm_mssql_database.server_id`
+var resourceIDArgumentPaths = map[string]string{
+	"azurerm_eventhub.namespace_id":                   "Microsoft.EventHub/namespaces",
+	"azurerm_mssql_database.server_id":                "Microsoft.Sql/servers",
+	"azurerm_mssql_firewall_rule.server_id":           "Microsoft.Sql/servers",
+	"azurerm_mssql_virtual_network_rule.server_id":    "Microsoft.Sql/servers",
+	"azurerm_servicebus_queue.namespace_id":           "Microsoft.ServiceBus/namespaces",
+	"azurerm_servicebus_subscription.topic_id":        "Microsoft.ServiceBus/namespaces/topics",
+	"azurerm_servicebus_topic.namespace_id":           "Microsoft.ServiceBus/namespaces",
+	"azurerm_virtual_network.ddos_protection_plan.id": "Microsoft.Network/ddosProtectionPlans",
+}
+
+// expectedARMType returns the ARM resource type expected by the argument at the path, e.g.
+// `["resource", "azurerm_network_interface", "ip_configuration", "subnet_id"]`
+func expectedARMType(path []string) (string, bool) {
+	if path[0] != "resource" && path[0] != "data" {
+		return "", false
+	}
+	if armType, ok := resourceIDArgumentPaths[strings.Join(path[1:], ".")]; ok {
+		return armType, true
+	}
+	armType, ok := resourceIDArguments[path[len(path)-1]]
+	return armType, ok
+}
@@ -0,0 +1,114 @@
+package rules
+
+import "strings"
+
+// azureRegion is an Azure region, Name is the programmatic name, e.g. `westeurope`, and DisplayName is e.g. `West Europe`
+type azureRegion struct {
+	Name        string
+	DisplayName string
+}
+
+// azureRegions are the physical regions of the Azure public, China and US Government clouds, sorted by name. They are the
+// output of the following command run in each of the clouds, selected by `az cloud set --name <AzureCloud|AzureChinaCloud|AzureUSGovernment>`,
+// and have to be refreshed when new regions are announced:
+//
+//	az account list-locations --query "[?metadata.regionType=='Physical'].[name, displayName]" -o tsv
+var azureRegions = []azureRegion{
+	{Name: "australiacentral", DisplayName: "Australia Central"},
+	{Name: "australiacentral2", DisplayName: "Australia Central 2"},
+	{Name: "australiaeast", DisplayName: "Australia East"},
+	{Name: "australiasoutheast", DisplayName: "Australia Southeast"},
+	{Name: "austriaeast", DisplayName: "Austria East"},
+	{Name: "belgiumcentral", DisplayName: "Belgium Central"},
+	{Name: "brazilsouth", DisplayName: "Brazil South"},
+	{Name: "brazilsoutheast", DisplayName: "Brazil Southeast"},
+	{Name: "canadacentral", DisplayName: "Canada Central"},
+	{Name: "canadaeast", DisplayName: "Canada East"},
+	{Name: "centralindia", DisplayName: "Central India"},
+	{Name: "centralus", DisplayName: "Central US"},
+	{Name: "centraluseuap", DisplayName: "Central US EUAP"},
+	{Name: "chilecentral", DisplayName: "Chile Central"},
+	{Name: "chinaeast", DisplayName: "China East"},
+	{Name: "chinaeast2", DisplayName: "China East 2"},
+	{Name: "chinaeast3", DisplayName: "China East 3"},
+	{Name: "chinanorth", DisplayName: "China North"},
+	{Name: "chinanorth2", DisplayName: "China North 2"},
+	{Name: "chinanorth3", DisplayName: "China North 3"},
+	{Name: "denmarkeast", DisplayName: "Denmark East"},
+	{Name: "eastasia", DisplayName: "East Asia"},
+	{Name: "eastus", DisplayName: "East US"},
+	{Name: "eastus2", DisplayName: "East US 2"},
+	{Name: "eastus2euap", DisplayName: "East US 2 EUAP"},
+	{Name: "francecentral", DisplayName: "France Central"},
+	{Name: "francesouth", DisplayName: "France South"},
+	{Name: "germanynorth", DisplayName: "Germany North"},
+	{Name: "germanywestcentral", DisplayName: "Germany West Central"},
+	{Name: "indonesiacentral", DisplayName: "Indonesia Central"},
+	{Name: "israelcentral", DisplayName: "Israel Central"},
+	{Name: "italynorth", DisplayName: "Italy North"},
+	{Name: "japaneast", DisplayName: "Japan East"},
+	{Name: "japanwest", DisplayName: "Japan West"},
+	{Name: "jioindiacentral", DisplayName: "Jio India Central"},
+	{Name: "jioindiawest", DisplayName: "Jio India West"},
+	{Name: "koreacentral", DisplayName: "Korea Central"},
+	{Name: "koreasouth", DisplayName: "Korea South"},
+	{Name: "malaysiasouth", DisplayName: "Malaysia South"},
+	{Name: "malaysiawest", DisplayName: "Malaysia West"},
+	{Name: "mexicocentral", DisplayName: "Mexico Central"},
+	{Name: "newzealandnorth", DisplayName: "New Zealand North"},
+	{Name: "northcentralus", DisplayName: "North Central US"},
+	{Name: "northeurope", DisplayName: "North Europe"},
+	{Name: "norwayeast", DisplayName: "Norway East"},
+	{Name: "norwaywest", DisplayName: "Norway West"},
+	{Name: "polandcentral", DisplayName: "Poland Central"},
+	{Name: "qatarcentral", DisplayName: "Qatar Central"},
+	{Name: "southafricanorth", DisplayName: "South Africa North"},
+	{Name: "southafricawest", DisplayName: "South Africa West"},
+	{Name: "southcentralus", DisplayName: "South Central US"},
+	{Name: "southeastasia", DisplayName: "Southeast Asia"},
+	{Name: "southindia", DisplayName: "South India"},
+	{Name: "spaincentral", DisplayName: "Spain Central"},
+	{Name: "swedencentral", DisplayName: "Sweden Central"},
+	{Name: "swedensouth", DisplayName: "Sweden South"},
+	{Name: "switzerlandnorth", DisplayName: "Switzerland North"},
+	{Name: "switzerlandwest", DisplayName: "Switzerland West"},
+	{Name: "taiwannorth", DisplayName: "Taiwan North"},
+	{Name: "taiwannorthwest", DisplayName: "Taiwan Northwest"},
+	{Name: "uaecentral", DisplayName: "UAE Central"},
+	{Name: "uaenorth", DisplayName: "UAE North"},
+	{Name: "uksouth", DisplayName: "UK South"},
+	{Name: "ukwest", DisplayName: "UK West"},
+	{Name: "usdodcentral", DisplayName: "US DoD Central"},
+	{Name: "usdodeast", DisplayName: "US DoD East"},
+	{Name: "usgovarizona", DisplayName: "US Gov Arizona"},
+	{Name: "usgovtexas", DisplayName: "US Gov Texas"},
+	{Name: "usgovvirginia", DisplayName: "US Gov Virginia"},
+	{Name: "westcentralus", DisplayName: "West Central US"},
+	{Name: "westeurope", DisplayName: "West Europe"},
+	{Name: "westindia", DisplayName: "West India"},
+	{Name: "westus", DisplayName: "West US"},
+	{Name: "westus2", DisplayName: "West US 2"},
+	{Name: "westus3", DisplayName: "West US 3"},
+}
+
+// globalLocation is accepted by the resources which are not deployed to a region, e.g. `azurerm_monitor_action_group`
+const globalLocation = "global"
+
+var azureRegionsByName = func() map[string]*azureRegion {
+	regions := make(map[string]*azureRegion)
+	for i := range azureRegions {
+		regions[azureRegions[i].Name] = &azureRegions[i]
+	}
+	return regions
+}()
+
+// normalizeLocation normalizes a location the way the azurerm provider does, e.g. `West Europe` to `westeurope`
+func normalizeLocation(location string) string {
+	return strings.ToLower(strings.ReplaceAll(location, " ", ""))
+}
+
+// lookupRegion returns the region of a programmatic name or a display name, case-insensitively
+func lookupRegion(location string) (*azureRegion, bool) {
+	region, ok := azureRegionsByName[normalizeLocation(location)]
+	return region, ok
+}
@@ -0,0 +1,211 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.Rule = new(AzurermAllowedLocationRule)
+
+// AzurermAllowedLocationRule checks the locations of the azurerm resources against the configured allowed regions
+type AzurermAllowedLocationRule struct {
+	tflint.DefaultRule
+}
+
+// azurermAllowedLocationRuleConfig is the config of AzurermAllowedLocationRule
+type azurermAllowedLocationRuleConfig struct {
+	// Locations lists the regions allowed for all resource types
+	Locations []string `hclext:"locations,optional"`
+	// ResourceLocations lists the regions allowed for a resource type, in place of Locations
+	ResourceLocations map[string][]string `hclext:"resource_locations,optional"`
+}
+
+// allowedLocations are the programmatic names of the allowed regions, in the configured order
+type allowedLocations []string
+
+func (a allowedLocations) allows(location string) bool {
+	for _, name := range a {
+		if name == normalizeLocation(location) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a allowedLocations) String() string {
+	return "`" + strings.Join(a, "`, `") + "`"
+}
+
+// NewAzurermAllowedLocationRule returns a new rule
+func NewAzurermAllowedLocationRule() *AzurermAllowedLocationRule {
+	return &AzurermAllowedLocationRule{}
+}
+
+func (r *AzurermAllowedLocationRule) Name() string {
+	return "azurerm_allowed_location"
+}
+
+func (r *AzurermAllowedLocationRule) Enabled() bool {
+	return false
+}
+
+func (r *AzurermAllowedLocationRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *AzurermAllowedLocationRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks the `location` arguments of all resources at once, since a location may be taken from another resource,
+// e.g. `azurerm_resource_group.example.location`
+func (r *AzurermAllowedLocationRule) Check(runner tflint.Runner) error {
+	defaults, byType, err := r.allowedLocations(runner)
+	if err != nil {
+		return err
+	}
+	locations, err := locationArgs(runner)
+	if err != nil {
+		return err
+	}
+	resources := make(map[string]*locationArg)
+	var args []*locationArg
+	for _, l := range locations {
+		if len(l.Path) != 3 || l.Path[0] != "resource" || l.Path[2] != "location" || len(l.Block.Labels) < 2 {
+			continue
+		}
+		resources[l.Path[1]+"."+l.Block.Labels[1]] = l
+		args = append(args, l)
+	}
+	var issues []pendingIssue
+	for _, l := range args {
+		allowed, ok := byType[l.Path[1]]
+		if !ok {
+			allowed = defaults
+		}
+		if allowed == nil {
+			continue
+		}
+		subject := fmt.Sprintf("resource `%s.%s`", l.Path[1], l.Block.Labels[1])
+		value, source, known := resolveLocation(l, resources, make(map[*locationArg]bool))
+		switch {
+		case !known:
+			issues = append(issues, pendingIssue{
+				Message: fmt.Sprintf("location of %s cannot be determined statically, allowed locations are %s", subject, allowed),
+				Range:   l.Arg.Range,
+			})
+		case normalizeLocation(value) != globalLocation && !allowed.allows(value):
+			msg := fmt.Sprintf("location `%s` of %s is not allowed", value, subject)
+			if source != "" {
+				msg = fmt.Sprintf("location `%s` of %s taken from `%s` is not allowed", value, subject, source)
+			}
+			issues = append(issues, pendingIssue{
+				Message: fmt.Sprintf("%s, allowed locations are %s", msg, allowed),
+				Range:   l.Arg.Range,
+			})
+		}
+	}
+	return emitIssues(runner, r, issues)
+}
+
+// allowedLocations decodes the rule config, and returns the regions allowed by default and those allowed per resource type.
+// The default is nil if only some resource types are restricted
+func (r *AzurermAllowedLocationRule) allowedLocations(runner tflint.Runner) (allowedLocations, map[string]allowedLocations, error) {
+	config := azurermAllowedLocationRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return nil, nil, err
+	}
+	if len(config.Locations) == 0 && len(config.ResourceLocations) == 0 {
+		return nil, nil, fmt.Errorf("either `locations` or `resource_locations` of rule `%s` must be set", r.Name())
+	}
+	defaults, err := r.parseLocations(config.Locations)
+	if err != nil {
+		return nil, nil, err
+	}
+	byType := make(map[string]allowedLocations)
+	var types []string
+	for t := range config.ResourceLocations {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		// the resource types cannot be validated if no schema is close to the azurerm version of the module
+		if activeSchema != nil && queryBlockSchema([]string{"resource", t}) == nil {
+			return nil, nil, fmt.Errorf("invalid resource type `%s` in `resource_locations` of rule `%s`: not an azurerm resource", t, r.Name())
+		}
+		locations := config.ResourceLocations[t]
+		if len(locations) == 0 {
+			return nil, nil, fmt.Errorf("no location is allowed for resource type `%s` in `resource_locations` of rule `%s`", t, r.Name())
+		}
+		if byType[t], err = r.parseLocations(locations); err != nil {
+			return nil, nil, err
+		}
+	}
+	return defaults, byType, nil
+}
+
+func (r *AzurermAllowedLocationRule) parseLocations(locations []string) (allowedLocations, error) {
+	var allowed allowedLocations
+	for _, location := range locations {
+		region, ok := lookupRegion(location)
+		if !ok {
+			return nil, fmt.Errorf("invalid location of rule `%s`: %s", r.Name(), unknownRegionMessage(location))
+		}
+		allowed = append(allowed, region.Name)
+	}
+	return allowed, nil
+}
+
+// resolveLocation returns the location of the argument, following a reference to the `location` of another resource of
+// the module, e.g. `azurerm_resource_group.example.location`, in which case that resource is returned as the source
+func resolveLocation(l *locationArg, resources map[string]*locationArg, visited map[*locationArg]bool) (string, string, bool) {
+	if l.Known {
+		return l.Value, "", true
+	}
+	expr, ok := l.Arg.Expr.(*hclsyntax.ScopeTraversalExpr)
+	if !ok {
+		return "", "", false
+	}
+	address, ok := locationReference(expr.Traversal)
+	if !ok {
+		return "", "", false
+	}
+	ref, ok := resources[address]
+	// visited guards against resources referencing each other
+	if !ok || visited[ref] {
+		return "", "", false
+	}
+	visited[l] = true
+	value, source, known := resolveLocation(ref, resources, visited)
+	if source == "" {
+		source = address
+	}
+	return value, source, known
+}
+
+// locationReference returns the address of the resource referenced by `<type>.<name>.location`,
+// or `<type>.<name>[<key>].location` for resources with `count` or `for_each`
+func locationReference(traversal hcl.Traversal) (string, bool) {
+	if len(traversal) != 3 && len(traversal) != 4 {
+		return "", false
+	}
+	if _, ok := traversal[len(traversal)-1].(hcl.TraverseAttr); !ok || stepName(traversal[len(traversal)-1]) != "location" {
+		return "", false
+	}
+	name, ok := traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return "", false
+	}
+	if len(traversal) == 4 {
+		if _, ok := traversal[2].(hcl.TraverseIndex); !ok {
+			return "", false
+		}
+	}
+	return traversal.RootName() + "." + name.Name, true
+}
@@ -0,0 +1,255 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermAllowedLocationRule(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. allowed locations",
+			Files: map[string]string{
+				".tflint.hcl": `
+rule "azurerm_allowed_location" {
+  enabled   = true
+  locations = ["westeurope", "North Europe", "Belgium Central", "austriaeast"]
+}`,
+				"main.tf": `
+variable "location" {
+  default = "northeurope"
+}
+
+resource "azurerm_resource_group" "belgium" {
+  name     = "belgium"
+  location = "belgiumcentral"
+}
+
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "West Europe"
+}
+
+resource "azurerm_virtual_network" "example" {
+  name     = "example"
+  location = azurerm_resource_group.example.location
+}
+
+resource "azurerm_resource_group" "secondary" {
+  name     = "secondary"
+  location = var.location
+}
+
+resource "azurerm_monitor_action_group" "example" {
+  name     = "example"
+  location = "global"
+}
+
+resource "azurerm_monitor_action_group" "secondary" {
+  name     = "secondary"
+  location = "Global"
+}
+
+data "azurerm_resources" "example" {
+  location = "eastus"
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. disallowed locations",
+			Files: map[string]string{
+				".tflint.hcl": `
+rule "azurerm_allowed_location" {
+  enabled   = true
+  locations = ["westeurope", "northeurope"]
+}`,
+				"main.tf": `
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "eastus"
+}
+
+resource "azurerm_virtual_network" "example" {
+  count    = 2
+  name     = "example"
+  location = azurerm_resource_group.example.location
+}
+
+resource "azurerm_subnet" "example" {
+  name = "example"
+}
+
+resource "azurerm_network_security_group" "example" {
+  name     = "example"
+  location = azurerm_virtual_network.example[0].location
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermAllowedLocationRule(),
+					Message: "location `eastus` of resource `azurerm_resource_group.example` is not allowed, allowed locations are `westeurope`, `northeurope`",
+				},
+				{
+					Rule:    NewAzurermAllowedLocationRule(),
+					Message: "location `eastus` of resource `azurerm_virtual_network.example` taken from `azurerm_resource_group.example` is not allowed, allowed locations are `westeurope`, `northeurope`",
+				},
+				{
+					Rule:    NewAzurermAllowedLocationRule(),
+					Message: "location `eastus` of resource `azurerm_network_security_group.example` taken from `azurerm_resource_group.example` is not allowed, allowed locations are `westeurope`, `northeurope`",
+				},
+			},
+		},
+		{
+			Name: "3. locations which cannot be determined statically",
+			Files: map[string]string{
+				".tflint.hcl": `
+rule "azurerm_allowed_location" {
+  enabled   = true
+  locations = ["westeurope"]
+}`,
+				"main.tf": `
+variable "location" {
+  type = string
+}
+
+data "azurerm_resource_group" "example" {
+  name = "example"
+}
+
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = var.location
+}
+
+resource "azurerm_virtual_network" "example" {
+  name     = "example"
+  location = data.azurerm_resource_group.example.location
+}
+
+resource "azurerm_network_security_group" "example" {
+  name     = "example"
+  location = azurerm_network_security_group.example.location
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermAllowedLocationRule(),
+					Message: "location of resource `azurerm_resource_group.example` cannot be determined statically, allowed locations are `westeurope`",
+				},
+				{
+					Rule:    NewAzurermAllowedLocationRule(),
+					Message: "location of resource `azurerm_virtual_network.example` cannot be determined statically, allowed locations are `westeurope`",
+				},
+				{
+					Rule:    NewAzurermAllowedLocationRule(),
+					Message: "location of resource `azurerm_network_security_group.example` cannot be determined statically, allowed locations are `westeurope`",
+				},
+			},
+		},
+		{
+			Name: "4. locations per resource type",
+			Files: map[string]string{
+				".tflint.hcl": `
+rule "azurerm_allowed_location" {
+  enabled = true
+  resource_locations = {
+    azurerm_cognitive_account = ["eastus2"]
+  }
+}`,
+				"main.tf": `
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "westeurope"
+}
+
+resource "azurerm_cognitive_account" "example" {
+  name     = "example"
+  location = azurerm_resource_group.example.location
+}
+
+resource "azurerm_cognitive_account" "secondary" {
+  name     = "secondary"
+  location = "eastus2"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermAllowedLocationRule(),
+					Message: "location `westeurope` of resource `azurerm_cognitive_account.example` taken from `azurerm_resource_group.example` is not allowed, allowed locations are `eastus2`",
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermAllowedLocationRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, tc.Files)
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_AzurermAllowedLocationRule_InvalidConfig(t *testing.T) {
+	configs := map[string]string{
+		"no locations": `
+rule "azurerm_allowed_location" {
+  enabled = true
+}`,
+		"unknown region": `
+rule "azurerm_allowed_location" {
+  enabled   = true
+  locations = ["westeurope2"]
+}`,
+		"unknown resource type": `
+rule "azurerm_allowed_location" {
+  enabled = true
+  resource_locations = {
+    azurerm_unknown = ["eastus2"]
+  }
+}`,
+	}
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{".tflint.hcl": config})
+			if err := NewAzurermAllowedLocationRule().Check(runner); err == nil {
+				t.Fatal("Expected error for invalid config")
+			}
+		})
+	}
+}
+
+func Test_AzurermAllowedLocationRule_NoSchema(t *testing.T) {
+	t.Cleanup(func() {
+		useAzurermSchema(bundledSchemas[0])
+	})
+	useAzurermSchema(nil)
+	runner := helper.TestRunner(t, map[string]string{
+		"config.tf": `
+resource "azurerm_storage_account" "example" {
+  location = "westeurope"
+}`,
+		".tflint.hcl": `
+rule "azurerm_allowed_location" {
+  enabled = true
+  resource_locations = {
+    azurerm_storage_account = ["eastus2"]
+  }
+}`,
+	})
+	if err := NewAzurermAllowedLocationRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	AssertIssues(t, helper.Issues{}, runner.Issues)
+}
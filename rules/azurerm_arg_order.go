@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
-	"github.com/lonegunmanb/terraform-azurerm-schema/v4/generated"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
@@ -57,16 +56,12 @@ func (r *AzurermArgOrderRule) CheckFile(runner tflint.Runner, file *hcl.File) er
 	var err error
 	for _, block := range blocks {
 		var subErr error
-		typeWanted := linq.From([]string{"provider", "resource", "data"}).Contains(block.Type)
+		typeWanted := linq.From([]string{"provider", "resource", "data", "ephemeral"}).Contains(block.Type)
 		if !typeWanted {
 			continue
 		}
 		isAzProviderBlock := block.Type == "provider" && block.Labels[0] == "azurerm"
-		collection := generated.Resources
-		if block.Type == "data" {
-			collection = generated.DataSources
-		}
-		_, isAzBlock := collection[block.Labels[0]]
+		isAzBlock := queryBlockSchema([]string{block.Type, block.Labels[0]}) != nil
 		if typeWanted && (isAzProviderBlock || isAzBlock) {
 			subErr = r.visitAzBlock(runner, block)
 		}
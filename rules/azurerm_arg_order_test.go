@@ -29,6 +29,26 @@ provider "azurerm" {
   client_id = "temp"
   
   features {}
+}`,
+				},
+			},
+		},
+		{
+			Name: "11. ephemeral resource",
+			Content: `
+ephemeral "azurerm_key_vault_secret" "example" {
+  version      = "1"
+  name         = "secret"
+  key_vault_id = azurerm_key_vault.example.id
+}`,
+			Expected: helper.Issues{
+				{
+					Rule: NewAzurermArgOrderRule(),
+					Message: `Arguments are expected to be sorted in following order:
+ephemeral "azurerm_key_vault_secret" "example" {
+  key_vault_id = azurerm_key_vault.example.id
+  name         = "secret"
+  version      = "1"
 }`,
 				},
 			},
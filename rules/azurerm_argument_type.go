@@ -0,0 +1,206 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+var _ tflint.Rule = new(AzurermArgumentTypeRule)
+
+// AzurermArgumentTypeRule checks the values which can be folded into constants against the attribute types in the schema
+type AzurermArgumentTypeRule struct {
+	tflint.DefaultRule
+}
+
+// NewAzurermArgumentTypeRule returns a new rule
+func NewAzurermArgumentTypeRule() *AzurermArgumentTypeRule {
+	return &AzurermArgumentTypeRule{}
+}
+
+func (r *AzurermArgumentTypeRule) Name() string {
+	return "azurerm_argument_type"
+}
+
+func (r *AzurermArgumentTypeRule) Enabled() bool {
+	return true
+}
+
+func (r *AzurermArgumentTypeRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *AzurermArgumentTypeRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+func (r *AzurermArgumentTypeRule) Check(runner tflint.Runner) error {
+	locals, err := localAttributes(runner)
+	if err != nil {
+		return err
+	}
+	return Check(runner, func(runner tflint.Runner, file *hcl.File) error {
+		return r.checkFile(runner, file, locals)
+	})
+}
+
+// checkFile checks the types of the arguments in the provider, resource and data source blocks in the file
+func (r *AzurermArgumentTypeRule) checkFile(runner tflint.Runner, file *hcl.File, locals map[string]*hclsyntax.Attribute) error {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		logger.Debug("skip azurerm_argument_type since it's not hcl file")
+		return nil
+	}
+	var issues []pendingIssue
+	visitor := &BlockVisitor{
+		Arg: func(path []string, arg *Arg) error {
+			parent := queryBlockSchema(path[:len(path)-1])
+			if parent == nil {
+				return nil
+			}
+			attr, ok := parent.Attributes[arg.Name]
+			if !ok {
+				return nil
+			}
+			ty := attributeType(attr)
+			if ty == cty.NilType || ty == cty.DynamicPseudoType {
+				return nil
+			}
+			val, ok := constantValue(arg.Expr, locals, make(map[string]bool))
+			if !ok {
+				return nil
+			}
+			if _, err := convert.Convert(val, ty); err != nil {
+				issues = append(issues, pendingIssue{
+					Message: fmt.Sprintf("`%s` of %s `%s` must be %s: %s", strings.Join(path[2:], "."), blockKind(path[0]), path[1], ty.FriendlyName(), err.Error()),
+					Range:   arg.Range,
+				})
+			}
+			return nil
+		},
+	}
+	for _, block := range azurermBlocks(body) {
+		if err := BuildResourceBlock(block, file, nil).Walk(visitor); err != nil {
+			return err
+		}
+	}
+	return emitIssues(runner, r, issues)
+}
+
+// constantFunctions are the built-in functions of Terraform which return the same result for the same arguments and
+// don't depend on anything else, e.g. the file system
+var constantFunctions = map[string]function.Function{
+	"abs":        stdlib.AbsoluteFunc,
+	"ceil":       stdlib.CeilFunc,
+	"chomp":      stdlib.ChompFunc,
+	"coalesce":   stdlib.CoalesceFunc,
+	"compact":    stdlib.CompactFunc,
+	"concat":     stdlib.ConcatFunc,
+	"contains":   stdlib.ContainsFunc,
+	"distinct":   stdlib.DistinctFunc,
+	"element":    stdlib.ElementFunc,
+	"flatten":    stdlib.FlattenFunc,
+	"floor":      stdlib.FloorFunc,
+	"format":     stdlib.FormatFunc,
+	"join":       stdlib.JoinFunc,
+	"jsonencode": stdlib.JSONEncodeFunc,
+	"keys":       stdlib.KeysFunc,
+	"length":     stdlib.LengthFunc,
+	"lookup":     stdlib.LookupFunc,
+	"lower":      stdlib.LowerFunc,
+	"max":        stdlib.MaxFunc,
+	"merge":      stdlib.MergeFunc,
+	"min":        stdlib.MinFunc,
+	"replace":    stdlib.ReplaceFunc,
+	"reverse":    stdlib.ReverseListFunc,
+	"slice":      stdlib.SliceFunc,
+	"sort":       stdlib.SortFunc,
+	"split":      stdlib.SplitFunc,
+	"substr":     stdlib.SubstrFunc,
+	"title":      stdlib.TitleFunc,
+	"tobool":     stdlib.MakeToFunc(cty.Bool),
+	"tolist":     stdlib.MakeToFunc(cty.List(cty.DynamicPseudoType)),
+	"tomap":      stdlib.MakeToFunc(cty.Map(cty.DynamicPseudoType)),
+	"tonumber":   stdlib.MakeToFunc(cty.Number),
+	"toset":      stdlib.MakeToFunc(cty.Set(cty.DynamicPseudoType)),
+	"tostring":   stdlib.MakeToFunc(cty.String),
+	"trim":       stdlib.TrimFunc,
+	"trimprefix": stdlib.TrimPrefixFunc,
+	"trimspace":  stdlib.TrimSpaceFunc,
+	"trimsuffix": stdlib.TrimSuffixFunc,
+	"upper":      stdlib.UpperFunc,
+	"values":     stdlib.ValuesFunc,
+	"zipmap":     stdlib.ZipmapFunc,
+}
+
+// constantValue folds an expression which only consists of literals, calls of constantFunctions and locals which are
+// constants themselves, e.g. `tostring(1)` or `merge(local.tags, { env = "dev" })`
+func constantValue(expr hclsyntax.Expression, locals map[string]*hclsyntax.Attribute, visited map[string]bool) (cty.Value, bool) {
+	localValues := make(map[string]cty.Value)
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != "local" || len(traversal) < 2 {
+			return cty.NilVal, false
+		}
+		name := stepName(traversal[1])
+		if _, ok := localValues[name]; ok {
+			continue
+		}
+		local, ok := locals[name]
+		// visited guards against locals referencing each other
+		if !ok || visited[name] {
+			return cty.NilVal, false
+		}
+		visited[name] = true
+		val, ok := constantValue(local.Expr, locals, visited)
+		delete(visited, name)
+		if !ok {
+			return cty.NilVal, false
+		}
+		localValues[name] = val
+	}
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{"local": cty.ObjectVal(localValues)},
+		Functions: constantFunctions,
+	}
+	val, diags := expr.Value(ctx)
+	if diags.HasErrors() || !val.IsWhollyKnown() {
+		return cty.NilVal, false
+	}
+	return val, true
+}
+
+// attributeType returns the type of the attribute, or the type built from its nested attributes
+func attributeType(attr *tfjson.SchemaAttribute) cty.Type {
+	if attr.AttributeNestedType == nil {
+		return attr.AttributeType
+	}
+	nested := attr.AttributeNestedType
+	attrTypes := make(map[string]cty.Type)
+	var optional []string
+	for name, a := range nested.Attributes {
+		attrTypes[name] = attributeType(a)
+		if !a.Required {
+			optional = append(optional, name)
+		}
+	}
+	obj := cty.ObjectWithOptionalAttrs(attrTypes, optional)
+	switch nested.NestingMode {
+	case tfjson.SchemaNestingModeList:
+		return cty.List(obj)
+	case tfjson.SchemaNestingModeSet:
+		return cty.Set(obj)
+	case tfjson.SchemaNestingModeMap:
+		return cty.Map(obj)
+	}
+	return obj
+}
@@ -0,0 +1,177 @@
+package rules
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+func Test_AzurermArgumentTypeRule(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. convertible values",
+			Content: `
+resource "azurerm_kubernetes_cluster" "example" {
+  name                    = "example"
+  location                = var.location
+  private_cluster_enabled = "true"
+  tags                    = { env = "dev", cost_center = 1234 }
+
+  default_node_pool {
+    name       = "default"
+    node_count = "3"
+    zones      = [1, 2, 3]
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. values not convertible",
+			Content: `
+resource "azurerm_kubernetes_cluster" "example" {
+  name                    = "example"
+  private_cluster_enabled = "yes"
+  tags                    = ["dev"]
+
+  default_node_pool {
+    name       = "default"
+    node_count = 1 + 1 > 1 ? "three" : 3
+    zones      = "1"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermArgumentTypeRule(),
+					Message: "`private_cluster_enabled` of resource `azurerm_kubernetes_cluster` must be bool: a bool is required",
+				},
+				{
+					Rule:    NewAzurermArgumentTypeRule(),
+					Message: "`tags` of resource `azurerm_kubernetes_cluster` must be map of string: map of string required",
+				},
+				{
+					Rule:    NewAzurermArgumentTypeRule(),
+					Message: "`default_node_pool.node_count` of resource `azurerm_kubernetes_cluster` must be number: a number is required",
+				},
+				{
+					Rule:    NewAzurermArgumentTypeRule(),
+					Message: "`default_node_pool.zones` of resource `azurerm_kubernetes_cluster` must be set of string: set of string required",
+				},
+			},
+		},
+		{
+			Name: "3. nested element",
+			Content: `
+resource "azurerm_kubernetes_cluster" "example" {
+  name = "example"
+  tags = { env = ["dev"] }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermArgumentTypeRule(),
+					Message: "`tags` of resource `azurerm_kubernetes_cluster` must be map of string: element \"env\": string required",
+				},
+			},
+		},
+		{
+			Name: "4. provider block",
+			Content: `
+provider "azurerm" {
+  use_msi = "maybe"
+
+  features {
+    key_vault {
+      purge_soft_delete_on_destroy = 1
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermArgumentTypeRule(),
+					Message: "`use_msi` of provider `azurerm` must be bool: a bool is required",
+				},
+				{
+					Rule:    NewAzurermArgumentTypeRule(),
+					Message: "`features.key_vault.purge_soft_delete_on_destroy` of provider `azurerm` must be bool: bool required",
+				},
+			},
+		},
+		{
+			Name: "5. function calls and locals",
+			Content: `
+locals {
+  enabled   = "yes"
+  base_tags = { env = "dev" }
+  tags      = merge(local.base_tags, { owner = ["ops"] })
+  location  = var.location
+}
+
+resource "azurerm_kubernetes_cluster" "example" {
+  name                    = lower("EXAMPLE")
+  location                = local.location
+  private_cluster_enabled = local.enabled
+  tags                    = local.tags
+  dns_prefix              = timestamp()
+
+  default_node_pool {
+    name       = "default"
+    node_count = tonumber("3")
+    zones      = tostring(1)
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermArgumentTypeRule(),
+					Message: "`private_cluster_enabled` of resource `azurerm_kubernetes_cluster` must be bool: a bool is required",
+				},
+				{
+					Rule:    NewAzurermArgumentTypeRule(),
+					Message: "`tags` of resource `azurerm_kubernetes_cluster` must be map of string: element \"owner\": string required",
+				},
+				{
+					Rule:    NewAzurermArgumentTypeRule(),
+					Message: "`default_node_pool.zones` of resource `azurerm_kubernetes_cluster` must be set of string: set of string required",
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermArgumentTypeRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_AttributeType_NestedAttributes(t *testing.T) {
+	attr := &tfjson.SchemaAttribute{
+		AttributeNestedType: &tfjson.SchemaNestedAttributeType{
+			NestingMode: tfjson.SchemaNestingModeList,
+			Attributes: map[string]*tfjson.SchemaAttribute{
+				"name":     {AttributeType: cty.String, Required: true},
+				"priority": {AttributeType: cty.Number, Optional: true},
+			},
+		},
+	}
+	ty := attributeType(attr)
+	if _, err := convert.Convert(cty.TupleVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("rule")})}), ty); err != nil {
+		t.Fatalf("Expected optional nested attribute, got %+v", err)
+	}
+	_, err := convert.Convert(cty.TupleVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"priority": cty.NumberIntVal(100)})}), ty)
+	if err == nil || err.Error() != `element 0: attribute "name" is required` {
+		t.Fatalf("Expected missing required nested attribute, got %+v", err)
+	}
+}
@@ -0,0 +1,275 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var _ tflint.Rule = new(AzurermAttributeReferenceRule)
+
+// AzurermAttributeReferenceRule checks the references to the attributes of azurerm resources and data sources against their schemas
+type AzurermAttributeReferenceRule struct {
+	tflint.DefaultRule
+}
+
+// NewAzurermAttributeReferenceRule returns a new rule
+func NewAzurermAttributeReferenceRule() *AzurermAttributeReferenceRule {
+	return &AzurermAttributeReferenceRule{}
+}
+
+func (r *AzurermAttributeReferenceRule) Name() string {
+	return "azurerm_attribute_reference"
+}
+
+func (r *AzurermAttributeReferenceRule) Enabled() bool {
+	return true
+}
+
+func (r *AzurermAttributeReferenceRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *AzurermAttributeReferenceRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+func (r *AzurermAttributeReferenceRule) Check(runner tflint.Runner) error {
+	return Check(runner, r.CheckFile)
+}
+
+// CheckFile checks the references in all expressions of the file
+func (r *AzurermAttributeReferenceRule) CheckFile(runner tflint.Runner, file *hcl.File) error {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		logger.Debug("skip azurerm_attribute_reference since it's not hcl file")
+		return nil
+	}
+	issues := make(map[hcl.Pos]pendingIssue)
+	var visitBody func(body *hclsyntax.Body)
+	visitBody = func(body *hclsyntax.Body) {
+		for _, attr := range body.Attributes {
+			_ = hclsyntax.VisitAll(attr.Expr, func(node hclsyntax.Node) hcl.Diagnostics {
+				traversal, ok := absoluteTraversal(node)
+				if !ok {
+					return nil
+				}
+				// a traversal may be visited again as a part of an index or a splat expression
+				if issue, ok := r.checkTraversal(file, traversal); ok {
+					issues[issue.Range.Start] = issue
+				}
+				return nil
+			})
+		}
+		for _, block := range body.Blocks {
+			visitBody(block.Body)
+		}
+	}
+	visitBody(body)
+	var sorted []pendingIssue
+	for _, issue := range issues {
+		sorted = append(sorted, issue)
+	}
+	return emitIssues(runner, r, sorted)
+}
+
+// absoluteTraversal returns the traversal of a reference, including the references with a dynamic index, e.g.
+// `azurerm_subnet.example[each.key].id`, and splat expressions, e.g. `azurerm_subnet.example[*].id`,
+// whose dynamic steps are returned as indexes with unknown keys
+func absoluteTraversal(node hclsyntax.Node) (hcl.Traversal, bool) {
+	switch e := node.(type) {
+	case *hclsyntax.ScopeTraversalExpr:
+		return e.Traversal, true
+	case *hclsyntax.RelativeTraversalExpr:
+		index, ok := e.Source.(*hclsyntax.IndexExpr)
+		if !ok {
+			return nil, false
+		}
+		source, ok := absoluteTraversal(index.Collection)
+		if !ok {
+			return nil, false
+		}
+		return joinTraversals(source, e.Traversal), true
+	case *hclsyntax.SplatExpr:
+		source, ok := absoluteTraversal(e.Source)
+		if !ok {
+			return nil, false
+		}
+		each, ok := e.Each.(*hclsyntax.RelativeTraversalExpr)
+		if !ok {
+			return nil, false
+		}
+		if _, ok := each.Source.(*hclsyntax.AnonSymbolExpr); !ok {
+			return nil, false
+		}
+		return joinTraversals(source, each.Traversal), true
+	}
+	return nil, false
+}
+
+func joinTraversals(source, relative hcl.Traversal) hcl.Traversal {
+	traversal := make(hcl.Traversal, 0, len(source)+len(relative)+1)
+	traversal = append(traversal, source...)
+	traversal = append(traversal, hcl.TraverseIndex{Key: cty.DynamicVal})
+	return append(traversal, relative...)
+}
+
+// checkTraversal checks a traversal rooted at an azurerm resource, data source or ephemeral resource, e.g.
+// `azurerm_storage_account.example.primary_blob_endpoint` or `data.azurerm_client_config.current.tenant_id`
+func (r *AzurermAttributeReferenceRule) checkTraversal(file *hcl.File, traversal hcl.Traversal) (pendingIssue, bool) {
+	kind := "resource"
+	if root := traversal.RootName(); root == "data" || root == "ephemeral" {
+		kind, traversal = root, traversal[1:]
+	}
+	if len(traversal) < 3 {
+		return pendingIssue{}, false
+	}
+	resourceType := stepName(traversal[0])
+	if !strings.HasPrefix(resourceType, "azurerm_") {
+		return pendingIssue{}, false
+	}
+	block := queryBlockSchema([]string{kind, resourceType})
+	if block == nil {
+		return pendingIssue{}, false
+	}
+	// the instance key of a resource with `count` or `for_each`
+	steps := skipIndexes(traversal[2:])
+	var path []string
+	var ty cty.Type
+	for len(steps) > 0 {
+		if block == nil {
+			// the value of an attribute
+			next, ok := attributeStep(ty, steps[0])
+			if !ok {
+				return pendingIssue{}, false
+			}
+			if next.name != "" {
+				path = append(path, next.name)
+			}
+			if next.invalid {
+				return r.issue(file, kind, resourceType, path, steps[0].(hcl.TraverseAttr), objectAttributeNames(ty)), true
+			}
+			ty, steps = next.ty, steps[1:]
+			continue
+		}
+		step, ok := steps[0].(hcl.TraverseAttr)
+		if !ok {
+			return pendingIssue{}, false
+		}
+		path = append(path, step.Name)
+		if attr, ok := block.Attributes[step.Name]; ok {
+			block, ty, steps = nil, attributeType(attr), steps[1:]
+			continue
+		}
+		nb, ok := block.NestedBlocks[step.Name]
+		if !ok {
+			return r.issue(file, kind, resourceType, path, step, blockMemberNames(block)), true
+		}
+		block, steps = nb.Block, steps[1:]
+		if nb.NestingMode != tfjson.SchemaNestingModeSingle && nb.NestingMode != tfjson.SchemaNestingModeGroup {
+			steps = skipIndexes(steps)
+		}
+	}
+	return pendingIssue{}, false
+}
+
+func skipIndexes(steps hcl.Traversal) hcl.Traversal {
+	for len(steps) > 0 {
+		if _, ok := steps[0].(hcl.TraverseIndex); !ok {
+			break
+		}
+		steps = steps[1:]
+	}
+	return steps
+}
+
+// typeStep is the result of a traversal step into a value
+type typeStep struct {
+	ty cty.Type
+	// name is the attribute name of an object
+	name string
+	// invalid is true if the object has no such attribute
+	invalid bool
+}
+
+// attributeStep applies a traversal step to a value of the type, false if the type is unknown or cannot be traversed
+func attributeStep(ty cty.Type, step hcl.Traverser) (typeStep, bool) {
+	switch {
+	case ty == cty.NilType:
+		return typeStep{}, false
+	case ty.IsObjectType():
+		var name string
+		switch s := step.(type) {
+		case hcl.TraverseAttr:
+			name = s.Name
+		case hcl.TraverseIndex:
+			if s.Key.Type() != cty.String || !s.Key.IsKnown() {
+				return typeStep{}, false
+			}
+			name = s.Key.AsString()
+		default:
+			return typeStep{}, false
+		}
+		if !ty.HasAttribute(name) {
+			_, isAttr := step.(hcl.TraverseAttr)
+			return typeStep{name: name, invalid: isAttr}, isAttr
+		}
+		return typeStep{ty: ty.AttributeType(name), name: name}, true
+	case ty.IsListType() || ty.IsSetType() || ty.IsMapType():
+		if _, ok := step.(hcl.TraverseIndex); !ok {
+			return typeStep{}, false
+		}
+		return typeStep{ty: ty.ElementType()}, true
+	}
+	return typeStep{}, false
+}
+
+func (r *AzurermAttributeReferenceRule) issue(file *hcl.File, kind, resourceType string, path []string, step hcl.TraverseAttr, candidates []string) pendingIssue {
+	msg := fmt.Sprintf("`%s` is not an attribute of %s `%s`", strings.Join(path, "."), blockKind(kind), resourceType)
+	suggestions := suggestNames(step.Name, candidates)
+	if len(suggestions) > 0 {
+		msg = fmt.Sprintf("%s, did you mean `%s`?", msg, strings.Join(suggestions, "` or `"))
+	}
+	issue := pendingIssue{Message: msg, Range: step.SrcRange}
+	rng := step.SrcRange
+	if len(suggestions) == 1 && rng.End.Byte <= len(file.Bytes) && strings.HasSuffix(string(file.Bytes[rng.Start.Byte:rng.End.Byte]), step.Name) {
+		// the range may include the leading dot
+		nameRange := rng
+		nameRange.Start.Byte = rng.End.Byte - len(step.Name)
+		nameRange.Start.Column = rng.End.Column - len(step.Name)
+		suggestion := suggestions[0]
+		issue.Fix = func(f tflint.Fixer) error {
+			return f.ReplaceText(nameRange, suggestion)
+		}
+	}
+	return issue
+}
+
+func blockMemberNames(block *tfjson.SchemaBlock) []string {
+	var names []string
+	for name := range block.Attributes {
+		names = append(names, name)
+	}
+	for name := range block.NestedBlocks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func objectAttributeNames(ty cty.Type) []string {
+	var names []string
+	for name := range ty.AttributeTypes() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
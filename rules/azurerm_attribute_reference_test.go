@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermAttributeReferenceRule(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+		Fixed    string
+	}{
+		{
+			Name: "1. valid references",
+			Content: `
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_key_vault" "example" {
+  tenant_id = data.azurerm_client_config.current.tenant_id
+}
+
+output "endpoints" {
+  value = {
+    blob     = azurerm_storage_account.example.primary_blob_endpoint
+    instance = azurerm_storage_account.counted[0].primary_blob_endpoint
+    each     = azurerm_storage_account.each[each.key].primary_web_host
+    all      = azurerm_storage_account.counted[*].id
+    network  = azurerm_storage_account.example.network_rules[0].default_action
+    rule     = azurerm_network_security_group.example.security_rule[0].name
+    cluster  = azurerm_kubernetes_cluster.example.kube_config[0].host
+    identity = azurerm_kubernetes_cluster.example.identity[0].principal_id
+    tags     = azurerm_storage_account.example.tags["env"]
+    module   = module.example.azurerm_storage_account
+    other    = random_string.example.result
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. unknown attributes",
+			Content: `
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_key_vault" "example" {
+  tenant_id = data.azurerm_client_config.current.tenantid
+}
+
+output "endpoints" {
+  value = {
+    blob    = azurerm_storage_account.example.primary_blob_endpoit
+    each    = azurerm_storage_account.each[each.key].primary_web_hosts
+    all     = azurerm_storage_account.counted[*].identifier
+    network = azurerm_storage_account.example.network_rules[0].default
+    rule    = azurerm_network_security_group.example.security_rule[0].nmae
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermAttributeReferenceRule(),
+					Message: "`tenantid` is not an attribute of data source `azurerm_client_config`, did you mean `tenant_id`?",
+				},
+				{
+					Rule:    NewAzurermAttributeReferenceRule(),
+					Message: "`primary_blob_endpoit` is not an attribute of resource `azurerm_storage_account`, did you mean `primary_blob_endpoint`?",
+				},
+				{
+					Rule:    NewAzurermAttributeReferenceRule(),
+					Message: "`primary_web_hosts` is not an attribute of resource `azurerm_storage_account`, did you mean `primary_web_host`?",
+				},
+				{
+					Rule:    NewAzurermAttributeReferenceRule(),
+					Message: "`identifier` is not an attribute of resource `azurerm_storage_account`",
+				},
+				{
+					Rule:    NewAzurermAttributeReferenceRule(),
+					Message: "`network_rules.default` is not an attribute of resource `azurerm_storage_account`, did you mean `default_action`?",
+				},
+				{
+					Rule:    NewAzurermAttributeReferenceRule(),
+					Message: "`security_rule.nmae` is not an attribute of resource `azurerm_network_security_group`, did you mean `name`?",
+				},
+			},
+			Fixed: `
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_key_vault" "example" {
+  tenant_id = data.azurerm_client_config.current.tenant_id
+}
+
+output "endpoints" {
+  value = {
+    blob    = azurerm_storage_account.example.primary_blob_endpoint
+    each    = azurerm_storage_account.each[each.key].primary_web_host
+    all     = azurerm_storage_account.counted[*].identifier
+    network = azurerm_storage_account.example.network_rules[0].default_action
+    rule    = azurerm_network_security_group.example.security_rule[0].name
+  }
+}`,
+		},
+	}
+
+	rule := NewAzurermAttributeReferenceRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+			if tc.Fixed == "" {
+				return
+			}
+			if fixed := string(runner.Changes()["config.tf"]); fixed != tc.Fixed {
+				t.Fatalf("Expected fixed config:\n%s\ngot:\n%s", tc.Fixed, fixed)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.Rule = new(AzurermComputedOnlyArgumentRule)
+
+// AzurermComputedOnlyArgumentRule checks whether computed-only attributes are assigned
+type AzurermComputedOnlyArgumentRule struct {
+	tflint.DefaultRule
+}
+
+// NewAzurermComputedOnlyArgumentRule returns a new rule
+func NewAzurermComputedOnlyArgumentRule() *AzurermComputedOnlyArgumentRule {
+	return &AzurermComputedOnlyArgumentRule{}
+}
+
+func (r *AzurermComputedOnlyArgumentRule) Name() string {
+	return "azurerm_computed_only_argument"
+}
+
+func (r *AzurermComputedOnlyArgumentRule) Enabled() bool {
+	return true
+}
+
+func (r *AzurermComputedOnlyArgumentRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *AzurermComputedOnlyArgumentRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+func (r *AzurermComputedOnlyArgumentRule) Check(runner tflint.Runner) error {
+	return Check(runner, r.CheckFile)
+}
+
+// CheckFile checks the resource and data source blocks in the file for assignments to computed-only attributes
+func (r *AzurermComputedOnlyArgumentRule) CheckFile(runner tflint.Runner, file *hcl.File) error {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		logger.Debug("skip azurerm_computed_only_argument since it's not hcl file")
+		return nil
+	}
+	var issues []pendingIssue
+	visitor := &BlockVisitor{
+		Arg: func(path []string, arg *Arg) error {
+			if computedOnly(path) {
+				issues = append(issues, pendingIssue{Message: computedOnlyMessage(path), Range: arg.Range})
+			}
+			return nil
+		},
+		// computed lists of objects, e.g. `identity` of data source `azurerm_kubernetes_cluster`, may be set as blocks
+		Block: func(path []string, nb *NestedBlock) error {
+			if computedOnly(path) {
+				issues = append(issues, pendingIssue{Message: computedOnlyMessage(path), Range: nb.DefRange()})
+			}
+			return nil
+		},
+	}
+	for _, block := range azurermBlocks(body) {
+		if err := BuildResourceBlock(block, file, nil).Walk(visitor); err != nil {
+			return err
+		}
+	}
+	return emitIssues(runner, r, issues)
+}
+
+// computedOnly checks whether the attribute at the path is computed but neither optional nor required.
+// `id` is optional in the schema of the resources and data sources, but it's set by the provider only
+func computedOnly(path []string) bool {
+	parent := queryBlockSchema(path[:len(path)-1])
+	if parent == nil {
+		return false
+	}
+	name := path[len(path)-1]
+	if len(path) == 3 && name == "id" && (path[0] == "resource" || path[0] == "data") {
+		return true
+	}
+	attr, ok := parent.Attributes[name]
+	return ok && isComputedOnly(attr)
+}
+
+func isComputedOnly(attr *tfjson.SchemaAttribute) bool {
+	return attr.Computed && !attr.Optional && !attr.Required
+}
+
+func computedOnlyMessage(path []string) string {
+	return fmt.Sprintf("`%s` of %s `%s` is computed by the provider and cannot be set", strings.Join(path[2:], "."), blockKind(path[0]), path[1])
+}
@@ -0,0 +1,129 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermComputedOnlyArgumentRule(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. optional computed arguments",
+			Content: `
+resource "azurerm_kubernetes_cluster" "example" {
+  name                = "example"
+  location            = "westeurope"
+  resource_group_name = "example"
+  dns_prefix          = "example"
+
+  identity {
+    type = "SystemAssigned"
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. computed-only arguments",
+			Content: `
+resource "azurerm_kubernetes_cluster" "example" {
+  id                  = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example"
+  name                = "example"
+  location            = "westeurope"
+  resource_group_name = "example"
+  fqdn                = "example.hcp.westeurope.azmk8s.io"
+
+  identity {
+    type         = "SystemAssigned"
+    principal_id = "00000000-0000-0000-0000-000000000000"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermComputedOnlyArgumentRule(),
+					Message: "`id` of resource `azurerm_kubernetes_cluster` is computed by the provider and cannot be set",
+				},
+				{
+					Rule:    NewAzurermComputedOnlyArgumentRule(),
+					Message: "`fqdn` of resource `azurerm_kubernetes_cluster` is computed by the provider and cannot be set",
+				},
+				{
+					Rule:    NewAzurermComputedOnlyArgumentRule(),
+					Message: "`identity.principal_id` of resource `azurerm_kubernetes_cluster` is computed by the provider and cannot be set",
+				},
+			},
+		},
+		{
+			Name: "3. dynamic block content",
+			Content: `
+resource "azurerm_kubernetes_cluster" "example" {
+  name = "example"
+
+  dynamic "identity" {
+    for_each = var.identity
+
+    content {
+      type      = identity.value.type
+      tenant_id = identity.value.tenant_id
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermComputedOnlyArgumentRule(),
+					Message: "`identity.tenant_id` of resource `azurerm_kubernetes_cluster` is computed by the provider and cannot be set",
+				},
+			},
+		},
+		{
+			Name: "4. computed-only block of data source",
+			Content: `
+data "azurerm_kubernetes_cluster" "example" {
+  name                = "example"
+  resource_group_name = "example"
+  fqdn                = "example.hcp.westeurope.azmk8s.io"
+
+  identity {
+    type = "SystemAssigned"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermComputedOnlyArgumentRule(),
+					Message: "`fqdn` of data source `azurerm_kubernetes_cluster` is computed by the provider and cannot be set",
+				},
+				{
+					Rule:    NewAzurermComputedOnlyArgumentRule(),
+					Message: "`identity` of data source `azurerm_kubernetes_cluster` is computed by the provider and cannot be set",
+				},
+			},
+		},
+		{
+			Name: "5. id of ephemeral resource is left to azurerm_unknown_argument",
+			Content: `
+ephemeral "azurerm_key_vault_secret" "example" {
+  id           = "secret"
+  name         = "secret"
+  key_vault_id = var.key_vault_id
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermComputedOnlyArgumentRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
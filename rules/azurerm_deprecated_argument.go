@@ -0,0 +1,133 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// providerSettingReplacement is the azurerm provider setting replacing a deprecated one
+type providerSettingReplacement struct {
+	Replacement string
+	// Values maps the literal values of the deprecated setting to the equivalent values of the replacement
+	Values map[string]string
+}
+
+// providerSettingReplacements are the deprecated azurerm provider settings which are fixed by `tflint --fix`
+var providerSettingReplacements = map[string]providerSettingReplacement{
+	"skip_provider_registration": {
+		Replacement: "resource_provider_registrations",
+		Values: map[string]string{
+			"true":  `"none"`,
+			"false": `"legacy"`,
+		},
+	},
+}
+
+var _ tflint.Rule = new(AzurermDeprecatedArgumentRule)
+
+// AzurermDeprecatedArgumentRule checks whether deprecated arguments or nested blocks are used
+type AzurermDeprecatedArgumentRule struct {
+	tflint.DefaultRule
+}
+
+// NewAzurermDeprecatedArgumentRule returns a new rule
+func NewAzurermDeprecatedArgumentRule() *AzurermDeprecatedArgumentRule {
+	return &AzurermDeprecatedArgumentRule{}
+}
+
+func (r *AzurermDeprecatedArgumentRule) Name() string {
+	return "azurerm_deprecated_argument"
+}
+
+func (r *AzurermDeprecatedArgumentRule) Enabled() bool {
+	return true
+}
+
+func (r *AzurermDeprecatedArgumentRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *AzurermDeprecatedArgumentRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+func (r *AzurermDeprecatedArgumentRule) Check(runner tflint.Runner) error {
+	return Check(runner, r.CheckFile)
+}
+
+// CheckFile checks the provider, resource and data source blocks in the file for deprecated arguments and nested blocks
+func (r *AzurermDeprecatedArgumentRule) CheckFile(runner tflint.Runner, file *hcl.File) error {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		logger.Debug("skip azurerm_deprecated_argument since it's not hcl file")
+		return nil
+	}
+	var issues []pendingIssue
+	var current *hclsyntax.Block
+	visitor := &BlockVisitor{
+		Arg: func(path []string, arg *Arg) error {
+			parent := queryBlockSchema(path[:len(path)-1])
+			if parent == nil {
+				return nil
+			}
+			if attr, ok := parent.Attributes[arg.Name]; ok && attr.Deprecated {
+				issue := pendingIssue{Message: deprecatedMessage(path, attr.Description), Range: arg.Range}
+				if path[0] == "provider" && len(path) == 3 {
+					replaceProviderSetting(&issue, current, arg)
+				}
+				issues = append(issues, issue)
+			}
+			return nil
+		},
+		Block: func(path []string, nb *NestedBlock) error {
+			parent := queryBlockSchema(path[:len(path)-1])
+			if parent == nil {
+				return nil
+			}
+			if nbSchema, ok := parent.NestedBlocks[nb.Name]; ok && nbSchema.Block != nil && nbSchema.Block.Deprecated {
+				issues = append(issues, pendingIssue{Message: deprecatedMessage(path, nbSchema.Block.Description), Range: nb.DefRange()})
+			}
+			return nil
+		},
+	}
+	for _, block := range azurermBlocks(body) {
+		current = block
+		if err := BuildResourceBlock(block, file, nil).Walk(visitor); err != nil {
+			return err
+		}
+	}
+	return emitIssues(runner, r, issues)
+}
+
+// deprecatedMessage describes the deprecated argument or nested block at the path, with the deprecation notice in
+// its description if any
+func deprecatedMessage(path []string, description string) string {
+	msg := fmt.Sprintf("`%s` of %s `%s` is deprecated", strings.Join(path[2:], "."), blockKind(path[0]), path[1])
+	if description = strings.TrimSpace(description); description != "" {
+		msg = fmt.Sprintf("%s: %s", msg, description)
+	}
+	return msg
+}
+
+// replaceProviderSetting attaches the fix replacing the deprecated provider setting set to a literal, e.g.
+// `skip_provider_registration = true` by `resource_provider_registrations = "none"`, unless the replacement is set
+func replaceProviderSetting(issue *pendingIssue, block *hclsyntax.Block, arg *Arg) {
+	replacement, ok := providerSettingReplacements[arg.Name]
+	if !ok {
+		return
+	}
+	value, known := replacement.Values[string(arg.Expr.Range().SliceBytes(arg.File.Bytes))]
+	if _, exists := block.Body.Attributes[replacement.Replacement]; !known || exists {
+		return
+	}
+	rng, text := arg.Range, fmt.Sprintf("%s = %s", replacement.Replacement, value)
+	issue.Fix = func(f tflint.Fixer) error {
+		return f.ReplaceText(rng, text)
+	}
+}
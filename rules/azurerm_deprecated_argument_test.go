@@ -0,0 +1,181 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermDeprecatedArgumentRule(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+		Fixed    string
+	}{
+		{
+			Name: "1. no deprecated argument",
+			Content: `
+resource "azurerm_storage_container" "example" {
+  name               = "content"
+  storage_account_id = azurerm_storage_account.example.id
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. deprecated argument",
+			Content: `
+resource "azurerm_storage_container" "example" {
+  name                 = "content"
+  storage_account_name = azurerm_storage_account.example.name
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermDeprecatedArgumentRule(),
+					Message: "`storage_account_name` of resource `azurerm_storage_container` is deprecated",
+				},
+			},
+		},
+		{
+			Name: "3. deprecated argument in nested block",
+			Content: `
+resource "azurerm_logic_app_standard" "example" {
+  name = "example"
+
+  site_config {
+    public_network_access_enabled = false
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermDeprecatedArgumentRule(),
+					Message: "`site_config.public_network_access_enabled` of resource `azurerm_logic_app_standard` is deprecated",
+				},
+			},
+		},
+		{
+			Name: "4. deprecated nested blocks",
+			Content: `
+resource "azurerm_monitor_diagnostic_setting" "example" {
+  name               = "example"
+  target_resource_id = azurerm_key_vault.example.id
+
+  enabled_log {
+    category = "AuditEvent"
+
+    retention_policy {
+      enabled = false
+    }
+  }
+  dynamic "metric" {
+    for_each = ["AllMetrics"]
+
+    content {
+      category = metric.value
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermDeprecatedArgumentRule(),
+					Message: "`enabled_log.retention_policy` of resource `azurerm_monitor_diagnostic_setting` is deprecated",
+				},
+				{
+					Rule:    NewAzurermDeprecatedArgumentRule(),
+					Message: "`metric` of resource `azurerm_monitor_diagnostic_setting` is deprecated",
+				},
+			},
+		},
+		{
+			Name: "5. data source",
+			Content: `
+data "azurerm_servicebus_queue" "example" {
+  name           = "example"
+  namespace_id   = azurerm_servicebus_namespace.example.id
+  namespace_name = azurerm_servicebus_namespace.example.name
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermDeprecatedArgumentRule(),
+					Message: "`namespace_name` of data source `azurerm_servicebus_queue` is deprecated",
+				},
+			},
+		},
+		{
+			Name: "6. provider block with deprecation notice",
+			Content: `
+provider "azurerm" {
+  skip_provider_registration = true
+
+  features {}
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermDeprecatedArgumentRule(),
+					Message: "`skip_provider_registration` of provider `azurerm` is deprecated: Should the AzureRM Provider skip registering all of the Resource Providers that it supports, if they're not already registered? This field is deprecated and will be removed in v5.0 of the AzureRM Provider, please use the `resource_provider_registrations` property instead.",
+				},
+			},
+			Fixed: `
+provider "azurerm" {
+  resource_provider_registrations = "none"
+
+  features {}
+}`,
+		},
+		{
+			Name: "7. replaced provider setting without fix",
+			Content: `
+provider "azurerm" {
+  skip_provider_registration = var.skip_provider_registration
+  features {}
+}
+
+provider "azurerm" {
+  alias                           = "connectivity"
+  skip_provider_registration      = false
+  resource_provider_registrations = "core"
+  features {}
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermDeprecatedArgumentRule(),
+					Message: "`skip_provider_registration` of provider `azurerm` is deprecated: Should the AzureRM Provider skip registering all of the Resource Providers that it supports, if they're not already registered? This field is deprecated and will be removed in v5.0 of the AzureRM Provider, please use the `resource_provider_registrations` property instead.",
+				},
+				{
+					Rule:    NewAzurermDeprecatedArgumentRule(),
+					Message: "`skip_provider_registration` of provider `azurerm` is deprecated: Should the AzureRM Provider skip registering all of the Resource Providers that it supports, if they're not already registered? This field is deprecated and will be removed in v5.0 of the AzureRM Provider, please use the `resource_provider_registrations` property instead.",
+				},
+			},
+		},
+		{
+			Name: "8. not azurerm block",
+			Content: `
+resource "azapi_resource" "example" {
+  storage_account_name = "example"
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermDeprecatedArgumentRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+			if tc.Fixed == "" {
+				if changes := runner.Changes(); len(changes) != 0 {
+					t.Fatalf("Expected no fix, got %d changed files", len(changes))
+				}
+				return
+			}
+			if fixed := string(runner.Changes()["config.tf"]); fixed != tc.Fixed {
+				t.Fatalf("Expected fixed config:\n%s\ngot:\n%s", tc.Fixed, fixed)
+			}
+		})
+	}
+}
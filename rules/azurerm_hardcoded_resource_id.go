@@ -0,0 +1,201 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// resourceIDExemptBlocks are the blocks where hard-coded resource IDs are expected,
+// e.g. the default values of variables and the IDs of imported resources
+var resourceIDExemptBlocks = map[string]bool{
+	"import":    true,
+	"terraform": true,
+	"variable":  true,
+}
+
+var _ tflint.Rule = new(AzurermHardcodedResourceIDRule)
+
+// AzurermHardcodedResourceIDRule checks for ARM resource IDs written as literals
+type AzurermHardcodedResourceIDRule struct {
+	tflint.DefaultRule
+}
+
+// resourceIDTarget is a resource or a data source of the module whose `id` can replace a hard-coded resource ID
+type resourceIDTarget struct {
+	// Address is e.g. `azurerm_subnet.example` or `data.azurerm_subnet.example`
+	Address       string
+	ARMType       string
+	Name          string
+	ResourceGroup string
+	Block         *hclsyntax.Block
+}
+
+// NewAzurermHardcodedResourceIDRule returns a new rule
+func NewAzurermHardcodedResourceIDRule() *AzurermHardcodedResourceIDRule {
+	return &AzurermHardcodedResourceIDRule{}
+}
+
+func (r *AzurermHardcodedResourceIDRule) Name() string {
+	return "azurerm_hardcoded_resource_id"
+}
+
+func (r *AzurermHardcodedResourceIDRule) Enabled() bool {
+	return true
+}
+
+func (r *AzurermHardcodedResourceIDRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *AzurermHardcodedResourceIDRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+func (r *AzurermHardcodedResourceIDRule) Check(runner tflint.Runner) error {
+	targets, err := resourceIDTargets(runner)
+	if err != nil {
+		return err
+	}
+	return Check(runner, func(runner tflint.Runner, file *hcl.File) error {
+		return r.checkFile(runner, file, targets)
+	})
+}
+
+func (r *AzurermHardcodedResourceIDRule) checkFile(runner tflint.Runner, file *hcl.File, targets []*resourceIDTarget) error {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		logger.Debug("skip azurerm_hardcoded_resource_id since it's not hcl file")
+		return nil
+	}
+	var issues []pendingIssue
+	var visitBody func(body *hclsyntax.Body)
+	visitBody = func(body *hclsyntax.Body) {
+		for _, attr := range body.Attributes {
+			name := attr.Name
+			_ = hclsyntax.VisitAll(attr.Expr, func(node hclsyntax.Node) hcl.Diagnostics {
+				if issue, ok := r.checkLiteral(file, name, node, targets); ok {
+					issues = append(issues, issue)
+				}
+				return nil
+			})
+		}
+		for _, block := range body.Blocks {
+			visitBody(block.Body)
+		}
+	}
+	for _, block := range body.Blocks {
+		if !resourceIDExemptBlocks[block.Type] {
+			visitBody(block.Body)
+		}
+	}
+	return emitIssues(runner, r, issues)
+}
+
+func (r *AzurermHardcodedResourceIDRule) checkLiteral(file *hcl.File, attrName string, node hclsyntax.Node, targets []*resourceIDTarget) (pendingIssue, bool) {
+	expr, ok := node.(*hclsyntax.TemplateExpr)
+	if !ok || !expr.IsStringLiteral() {
+		return pendingIssue{}, false
+	}
+	val, ok := staticValue(expr)
+	if !ok || val.Type() != cty.String || !looksLikeResourceID(val.AsString()) {
+		return pendingIssue{}, false
+	}
+	id, err := parseResourceID(val.AsString())
+	if err != nil {
+		return pendingIssue{}, false
+	}
+	rng := expr.Range()
+	issue := pendingIssue{
+		Message: fmt.Sprintf("hard-coded ID of `%s` `%s` in `%s`", id.Type(), id.Name(), attrName),
+		Range:   rng,
+	}
+	var references []string
+	for _, target := range targets {
+		if target.matches(id) && !containsRange(target.Block.Range(), rng) {
+			references = append(references, target.Address+".id")
+		}
+	}
+	if len(references) == 0 {
+		return issue, true
+	}
+	issue.Message = fmt.Sprintf("%s, reference `%s` instead", issue.Message, strings.Join(references, "` or `"))
+	// heredocs are not replaced
+	if len(references) == 1 && rng.Start.Byte < len(file.Bytes) && file.Bytes[rng.Start.Byte] == '"' {
+		reference := references[0]
+		issue.Fix = func(f tflint.Fixer) error {
+			return f.ReplaceText(rng, reference)
+		}
+	}
+	return issue, true
+}
+
+func (t *resourceIDTarget) matches(id *armResourceID) bool {
+	if !sameARMType(t.ARMType, id.Type()) || !strings.EqualFold(t.Name, id.Name()) {
+		return false
+	}
+	return t.ResourceGroup == "" || id.ResourceGroup == "" || strings.EqualFold(t.ResourceGroup, id.ResourceGroup)
+}
+
+func containsRange(outer, inner hcl.Range) bool {
+	return outer.Filename == inner.Filename && outer.Start.Byte <= inner.Start.Byte && inner.End.Byte <= outer.End.Byte
+}
+
+// resourceIDTargets collects the resources and data sources of the module whose ARM resource type is known and whose
+// name can be evaluated, resources with `count` or `for_each` are skipped
+func resourceIDTargets(runner tflint.Runner) ([]*resourceIDTarget, error) {
+	files, err := runner.GetFiles()
+	if err != nil {
+		return nil, err
+	}
+	var targets []*resourceIDTarget
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if (block.Type != "resource" && block.Type != "data") || len(block.Labels) < 2 {
+				continue
+			}
+			armType, ok := armResourceTypes[block.Labels[0]]
+			if !ok {
+				continue
+			}
+			if _, ok := block.Body.Attributes["count"]; ok {
+				continue
+			}
+			if _, ok := block.Body.Attributes["for_each"]; ok {
+				continue
+			}
+			nameAttr, ok := block.Body.Attributes["name"]
+			if !ok {
+				continue
+			}
+			name, ok := evaluateString(runner, nameAttr.Expr)
+			if !ok {
+				continue
+			}
+			target := &resourceIDTarget{
+				Address: block.Labels[0] + "." + block.Labels[1],
+				ARMType: armType,
+				Name:    name,
+				Block:   block,
+			}
+			if block.Type == "data" {
+				target.Address = "data." + target.Address
+			}
+			if rg, ok := block.Body.Attributes["resource_group_name"]; ok {
+				target.ResourceGroup, _ = evaluateString(runner, rg.Expr)
+			}
+			targets = append(targets, target)
+		}
+	}
+	return targets, nil
+}
@@ -0,0 +1,176 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermHardcodedResourceIDRule(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+		Fixed    string
+	}{
+		{
+			Name: "1. references and variables",
+			Content: `
+variable "subnet_id" {
+  default = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/snet"
+}
+
+import {
+  to = azurerm_resource_group.example
+  id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example"
+}
+
+resource "azurerm_network_interface" "example" {
+  name = "nic"
+
+  ip_configuration {
+    name      = "internal"
+    subnet_id = var.subnet_id
+  }
+}
+
+resource "azurerm_role_assignment" "example" {
+  scope              = "/subscriptions/${var.subscription_id}"
+  role_definition_id = "/providers/Microsoft.Authorization/roleDefinitions/acdd72a7-3385-48ef-bd42-f606fba81ae7"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. hard-coded resource IDs",
+			Content: `
+resource "azurerm_network_interface" "example" {
+  name = "nic"
+
+  ip_configuration {
+    name      = "internal"
+    subnet_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/snet"
+  }
+}
+
+resource "azurerm_role_assignment" "example" {
+  scope = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example"
+}
+
+locals {
+  workspace_ids = ["/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.OperationalInsights/workspaces/log"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermHardcodedResourceIDRule(),
+					Message: "hard-coded ID of `Microsoft.Network/virtualNetworks/subnets` `snet` in `subnet_id`",
+				},
+				{
+					Rule:    NewAzurermHardcodedResourceIDRule(),
+					Message: "hard-coded ID of `Microsoft.Resources/resourceGroups` `example` in `scope`",
+				},
+				{
+					Rule:    NewAzurermHardcodedResourceIDRule(),
+					Message: "hard-coded ID of `Microsoft.OperationalInsights/workspaces` `log` in `workspace_ids`",
+				},
+			},
+		},
+		{
+			Name: "3. suggest matching resources and data sources",
+			Content: `
+data "azurerm_subnet" "example" {
+  name                 = "snet"
+  resource_group_name  = "rg"
+  virtual_network_name = "vnet"
+}
+
+data "azurerm_subnet" "other" {
+  name                 = "snet"
+  resource_group_name  = "other-rg"
+  virtual_network_name = "vnet"
+}
+
+resource "azurerm_network_interface" "example" {
+  name = "nic"
+
+  ip_configuration {
+    name      = "internal"
+    subnet_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/snet"
+  }
+}
+
+resource "azurerm_log_analytics_workspace" "example" {
+  name = "log"
+}
+
+resource "azurerm_log_analytics_workspace" "secondary" {
+  name = "LOG"
+}
+
+resource "azurerm_monitor_diagnostic_setting" "example" {
+  log_analytics_workspace_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.OperationalInsights/workspaces/log"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermHardcodedResourceIDRule(),
+					Message: "hard-coded ID of `Microsoft.Network/virtualNetworks/subnets` `snet` in `subnet_id`, reference `data.azurerm_subnet.example.id` instead",
+				},
+				{
+					Rule:    NewAzurermHardcodedResourceIDRule(),
+					Message: "hard-coded ID of `Microsoft.OperationalInsights/workspaces` `log` in `log_analytics_workspace_id`, reference `azurerm_log_analytics_workspace.example.id` or `azurerm_log_analytics_workspace.secondary.id` instead",
+				},
+			},
+			Fixed: `
+data "azurerm_subnet" "example" {
+  name                 = "snet"
+  resource_group_name  = "rg"
+  virtual_network_name = "vnet"
+}
+
+data "azurerm_subnet" "other" {
+  name                 = "snet"
+  resource_group_name  = "other-rg"
+  virtual_network_name = "vnet"
+}
+
+resource "azurerm_network_interface" "example" {
+  name = "nic"
+
+  ip_configuration {
+    name      = "internal"
+    subnet_id = data.azurerm_subnet.example.id
+  }
+}
+
+resource "azurerm_log_analytics_workspace" "example" {
+  name = "log"
+}
+
+resource "azurerm_log_analytics_workspace" "secondary" {
+  name = "LOG"
+}
+
+resource "azurerm_monitor_diagnostic_setting" "example" {
+  log_analytics_workspace_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.OperationalInsights/workspaces/log"
+}`,
+		},
+	}
+
+	rule := NewAzurermHardcodedResourceIDRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+			if tc.Fixed == "" {
+				return
+			}
+			if fixed := string(runner.Changes()["config.tf"]); fixed != tc.Fixed {
+				t.Fatalf("Expected fixed config:\n%s\ngot:\n%s", tc.Fixed, fixed)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var _ tflint.Rule = new(AzurermHardcodedSensitiveArgumentRule)
+
+// AzurermHardcodedSensitiveArgumentRule checks whether sensitive arguments are set to hard-coded values
+type AzurermHardcodedSensitiveArgumentRule struct {
+	tflint.DefaultRule
+}
+
+// NewAzurermHardcodedSensitiveArgumentRule returns a new rule
+func NewAzurermHardcodedSensitiveArgumentRule() *AzurermHardcodedSensitiveArgumentRule {
+	return &AzurermHardcodedSensitiveArgumentRule{}
+}
+
+func (r *AzurermHardcodedSensitiveArgumentRule) Name() string {
+	return "azurerm_hardcoded_sensitive_argument"
+}
+
+func (r *AzurermHardcodedSensitiveArgumentRule) Enabled() bool {
+	return true
+}
+
+func (r *AzurermHardcodedSensitiveArgumentRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *AzurermHardcodedSensitiveArgumentRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+func (r *AzurermHardcodedSensitiveArgumentRule) Check(runner tflint.Runner) error {
+	locals, err := localAttributes(runner)
+	if err != nil {
+		return err
+	}
+	return Check(runner, func(runner tflint.Runner, file *hcl.File) error {
+		return r.checkFile(runner, file, locals)
+	})
+}
+
+func (r *AzurermHardcodedSensitiveArgumentRule) checkFile(runner tflint.Runner, file *hcl.File, locals map[string]*hclsyntax.Attribute) error {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		logger.Debug("skip azurerm_hardcoded_sensitive_argument since it's not hcl file")
+		return nil
+	}
+	var issues []pendingIssue
+	visitor := &BlockVisitor{
+		Arg: func(path []string, arg *Arg) error {
+			parent := queryBlockSchema(path[:len(path)-1])
+			if parent == nil {
+				return nil
+			}
+			if attr, ok := parent.Attributes[arg.Name]; !ok || !attr.Sensitive {
+				return nil
+			}
+			source, ok := hardcoded(arg.Expr, locals, make(map[string]bool))
+			if !ok {
+				return nil
+			}
+			msg := fmt.Sprintf("sensitive argument `%s` of %s `%s` is hard-coded", strings.Join(path[2:], "."), blockKind(path[0]), path[1])
+			if source != "" {
+				msg = fmt.Sprintf("%s in `%s`", msg, source)
+			}
+			issues = append(issues, pendingIssue{Message: msg, Range: arg.Range})
+			return nil
+		},
+	}
+	for _, block := range azurermBlocks(body) {
+		if err := BuildResourceBlock(block, file, nil).Walk(visitor); err != nil {
+			return err
+		}
+	}
+	return emitIssues(runner, r, issues)
+}
+
+// hardcoded checks whether the expression is a non-empty literal, a template of literals, or only references locals
+// which are hard-coded, in which case the first of these locals is returned.
+// Any other reference, e.g. to a variable, a `random_password` or an ephemeral resource, is accepted
+func hardcoded(expr hclsyntax.Expression, locals map[string]*hclsyntax.Attribute, visited map[string]bool) (string, bool) {
+	traversals := expr.Variables()
+	if len(traversals) == 0 {
+		val, ok := staticValue(expr)
+		return "", ok && !val.IsNull() && !val.RawEquals(cty.StringVal(""))
+	}
+	source := ""
+	for _, traversal := range traversals {
+		if traversal.RootName() != "local" || len(traversal) < 2 {
+			return "", false
+		}
+		name := stepName(traversal[1])
+		local, ok := locals[name]
+		// visited guards against locals referencing each other
+		if !ok || visited[name] {
+			return "", false
+		}
+		visited[name] = true
+		_, ok = hardcoded(local.Expr, locals, visited)
+		delete(visited, name)
+		if !ok {
+			return "", false
+		}
+		if source == "" {
+			source = "local." + name
+		}
+	}
+	return source, true
+}
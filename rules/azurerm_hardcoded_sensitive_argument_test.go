@@ -0,0 +1,131 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermHardcodedSensitiveArgumentRule(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. sensitive arguments from accepted sources",
+			Content: `
+resource "random_password" "admin" {
+  length = 16
+}
+
+data "azurerm_key_vault_secret" "sql" {
+  name         = "sql-admin"
+  key_vault_id = var.key_vault_id
+}
+
+ephemeral "azurerm_key_vault_secret" "sql" {
+  name         = "sql-admin"
+  key_vault_id = var.key_vault_id
+}
+
+resource "azurerm_linux_virtual_machine" "example" {
+  admin_password = random_password.admin.result
+  custom_data    = base64encode(var.custom_data)
+}
+
+resource "azurerm_mssql_server" "example" {
+  administrator_login_password    = data.azurerm_key_vault_secret.sql.value
+  administrator_login_password_wo = ephemeral.azurerm_key_vault_secret.sql.value
+}
+
+resource "azurerm_key_vault_secret" "example" {
+  name  = "secret"
+  value = "${var.prefix}-secret"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. literals and templates of literals",
+			Content: `
+resource "azurerm_linux_virtual_machine" "example" {
+  admin_password = "P@ssw0rd1234!"
+  custom_data    = ""
+}
+
+resource "azurerm_mssql_server" "example" {
+  administrator_login_password = "${"P@ss"}w0rd"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermHardcodedSensitiveArgumentRule(),
+					Message: "sensitive argument `admin_password` of resource `azurerm_linux_virtual_machine` is hard-coded",
+				},
+				{
+					Rule:    NewAzurermHardcodedSensitiveArgumentRule(),
+					Message: "sensitive argument `administrator_login_password` of resource `azurerm_mssql_server` is hard-coded",
+				},
+			},
+		},
+		{
+			Name: "3. hard-coded locals",
+			Content: `
+locals {
+  password  = "P@ssw0rd1234!"
+  suffix    = "1234"
+  generated = random_password.admin.result
+}
+
+resource "azurerm_linux_virtual_machine" "example" {
+  admin_password = "P@ssw0rd${local.suffix}"
+}
+
+resource "azurerm_mssql_server" "example" {
+  administrator_login_password = local.password
+}
+
+resource "azurerm_key_vault_secret" "example" {
+  name  = "secret"
+  value = local.generated
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermHardcodedSensitiveArgumentRule(),
+					Message: "sensitive argument `admin_password` of resource `azurerm_linux_virtual_machine` is hard-coded in `local.suffix`",
+				},
+				{
+					Rule:    NewAzurermHardcodedSensitiveArgumentRule(),
+					Message: "sensitive argument `administrator_login_password` of resource `azurerm_mssql_server` is hard-coded in `local.password`",
+				},
+			},
+		},
+		{
+			Name: "4. hard-coded SAS key",
+			Content: `
+resource "azurerm_mssql_server_vulnerability_assessment" "example" {
+  server_security_alert_policy_id = azurerm_mssql_server_security_alert_policy.example.id
+  storage_container_path          = "https://example.blob.core.windows.net/assessment/"
+  storage_container_sas_key       = "sv=2020-08-04&ss=b&srt=sco&sp=rwdlacx"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermHardcodedSensitiveArgumentRule(),
+					Message: "sensitive argument `storage_container_sas_key` of resource `azurerm_mssql_server_vulnerability_assessment` is hard-coded",
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermHardcodedSensitiveArgumentRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/ahmetb/go-linq/v3"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// nonRegionLocationAttributes are the attributes named `*_location` whose values are not Azure regions,
+// e.g. `peering_location` of `azurerm_express_route_circuit`
+var nonRegionLocationAttributes = []string{
+	"cache_location",
+	"certificate_location",
+	"custom_location",
+	"data_location",
+	"data_residency_location",
+	"fabric_location",
+	"peering_location",
+	"secrets_location",
+	"store_location",
+}
+
+var _ tflint.Rule = new(AzurermLocationRule)
+
+// AzurermLocationRule checks the locations against the Azure regions, and whether they're named in the same style
+type AzurermLocationRule struct {
+	tflint.DefaultRule
+}
+
+// NewAzurermLocationRule returns a new rule
+func NewAzurermLocationRule() *AzurermLocationRule {
+	return &AzurermLocationRule{}
+}
+
+func (r *AzurermLocationRule) Name() string {
+	return "azurerm_location"
+}
+
+func (r *AzurermLocationRule) Enabled() bool {
+	return true
+}
+
+func (r *AzurermLocationRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *AzurermLocationRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks the locations of all files at once, since the naming style is compared across the module
+func (r *AzurermLocationRule) Check(runner tflint.Runner) error {
+	locations, err := locationArgs(runner)
+	if err != nil {
+		return err
+	}
+	var issues []pendingIssue
+	var literals []*locationArg
+	for _, l := range locations {
+		if !l.Known || normalizeLocation(l.Value) == globalLocation {
+			continue
+		}
+		if _, ok := lookupRegion(l.Value); !ok {
+			issues = append(issues, pendingIssue{Message: unknownRegionMessage(l.Value), Range: l.Arg.Range})
+			continue
+		}
+		if l.Literal {
+			literals = append(literals, l)
+		}
+	}
+	return emitIssues(runner, r, append(issues, locationStyleIssues(literals)...))
+}
+
+// locationStyleIssues reports the locations not named in the style used by most locations, either programmatic names
+// or display names, as well as those named in neither style, e.g. `WestEurope`
+func locationStyleIssues(locations []*locationArg) []pendingIssue {
+	programmatic, display := 0, 0
+	for _, l := range locations {
+		region, _ := lookupRegion(l.Value)
+		switch l.Value {
+		case region.Name:
+			programmatic++
+		case region.DisplayName:
+			display++
+		}
+	}
+	preferDisplay := display > programmatic
+	var issues []pendingIssue
+	for _, l := range locations {
+		region, _ := lookupRegion(l.Value)
+		preferred := region.Name
+		if preferDisplay {
+			preferred = region.DisplayName
+		}
+		if l.Value == preferred {
+			continue
+		}
+		var msg string
+		switch {
+		case l.Value == region.Name:
+			msg = fmt.Sprintf("`%s` is a programmatic name while most locations in the module are display names, use `%s`", l.Value, preferred)
+		case l.Value == region.DisplayName:
+			msg = fmt.Sprintf("`%s` is a display name while most locations in the module are programmatic names, use `%s`", l.Value, preferred)
+		default:
+			msg = fmt.Sprintf("`%s` is neither the programmatic name nor the display name of the region, use `%s`", l.Value, preferred)
+		}
+		rng, replacement := l.Arg.Expr.Range(), fmt.Sprintf("%q", preferred)
+		issues = append(issues, pendingIssue{
+			Message: msg,
+			Range:   l.Arg.Range,
+			Fix: func(f tflint.Fixer) error {
+				return f.ReplaceText(rng, replacement)
+			},
+		})
+	}
+	return issues
+}
+
+func unknownRegionMessage(location string) string {
+	var names []string
+	for _, region := range azureRegions {
+		names = append(names, region.Name)
+	}
+	msg := fmt.Sprintf("`%s` is not a known Azure region", location)
+	if suggestions := suggestNames(normalizeLocation(location), names); len(suggestions) > 0 {
+		msg = fmt.Sprintf("%s, did you mean `%s`?", msg, strings.Join(suggestions, "` or `"))
+	}
+	return msg
+}
+
+// locationArg is an argument of an azurerm block whose value is an Azure region
+type locationArg struct {
+	// Block is the top level block of the argument
+	Block *hclsyntax.Block
+	Path  []string
+	Arg   *Arg
+	// Value is the location if it can be evaluated
+	Value string
+	Known bool
+	// Literal is true if the location is written as a literal string
+	Literal bool
+}
+
+// locationArgs collects the `location` and `*_location` arguments whose values are Azure regions from the azurerm blocks
+// of the module, in the order of the files and the arguments in them
+func locationArgs(runner tflint.Runner) ([]*locationArg, error) {
+	files, err := runner.GetFiles()
+	if err != nil {
+		return nil, err
+	}
+	var locations []*locationArg
+	var current *hclsyntax.Block
+	visitor := &BlockVisitor{
+		Arg: func(path []string, arg *Arg) error {
+			if isRegionLocation(path) {
+				l := evaluateLocation(runner, path, arg)
+				l.Block = current
+				locations = append(locations, l)
+			}
+			return nil
+		},
+	}
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range azurermBlocks(body) {
+			current = block
+			if err = BuildResourceBlock(block, file, nil).Walk(visitor); err != nil {
+				return nil, err
+			}
+		}
+	}
+	sort.SliceStable(locations, func(i, j int) bool {
+		a, b := locations[i].Arg.Range, locations[j].Arg.Range
+		return a.Filename < b.Filename || a.Filename == b.Filename && a.Start.Byte < b.Start.Byte
+	})
+	return locations, nil
+}
+
+// isRegionLocation checks whether the attribute at the path is a configurable string attribute named `location`
+// or `*_location` whose value is an Azure region
+func isRegionLocation(path []string) bool {
+	name := path[len(path)-1]
+	if name != "location" && (!strings.HasSuffix(name, "_location") || linq.From(nonRegionLocationAttributes).Contains(name)) {
+		return false
+	}
+	parent := queryBlockSchema(path[:len(path)-1])
+	if parent == nil {
+		return false
+	}
+	attr, ok := parent.Attributes[name]
+	return ok && attr.AttributeType == cty.String && !isComputedOnly(attr)
+}
+
+func evaluateLocation(runner tflint.Runner, path []string, arg *Arg) *locationArg {
+	l := &locationArg{Path: path, Arg: arg}
+	val, ok := staticValue(arg.Expr)
+	if ok {
+		_, l.Literal = arg.Expr.(*hclsyntax.TemplateExpr)
+	} else if err := runner.EvaluateExpr(arg.Expr, &val, nil); err != nil {
+		return l
+	}
+	if val.IsNull() || !val.IsKnown() || val.IsMarked() || val.Type() != cty.String {
+		return l
+	}
+	l.Value, l.Known = val.AsString(), true
+	return l
+}
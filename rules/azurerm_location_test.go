@@ -0,0 +1,193 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermLocationRule(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+		Fixed    map[string]string
+	}{
+		{
+			Name: "1. valid locations in the same style",
+			Files: map[string]string{
+				"main.tf": `
+variable "location" {
+  default = "West Europe"
+}
+
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "westeurope"
+}
+
+resource "azurerm_resource_group" "secondary" {
+  name     = "secondary"
+  location = var.location
+}
+
+resource "azurerm_resource_group" "taiwan" {
+  name     = "taiwan"
+  location = "taiwannorth"
+}
+
+resource "azurerm_monitor_action_group" "example" {
+  name     = "example"
+  location = "global"
+}
+
+resource "azurerm_monitor_action_group" "secondary" {
+  name     = "secondary"
+  location = "Global"
+}
+
+resource "azurerm_express_route_circuit" "example" {
+  location         = "eastus2"
+  peering_location = "Silicon Valley"
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. unknown regions",
+			Files: map[string]string{
+				"main.tf": `
+variable "location" {
+  default = "Mars North"
+}
+
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "westeurope2"
+}
+
+resource "azurerm_resource_group" "secondary" {
+  name     = "secondary"
+  location = var.location
+}
+
+resource "azurerm_traffic_manager_external_endpoint" "example" {
+  endpoint_location = "West Erope"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermLocationRule(),
+					Message: "`westeurope2` is not a known Azure region, did you mean `westeurope`?",
+				},
+				{
+					Rule:    NewAzurermLocationRule(),
+					Message: "`Mars North` is not a known Azure region",
+				},
+				{
+					Rule:    NewAzurermLocationRule(),
+					Message: "`West Erope` is not a known Azure region, did you mean `westeurope`?",
+				},
+			},
+		},
+		{
+			Name: "3. inconsistent styles across files",
+			Files: map[string]string{
+				"a.tf": `
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "West Europe"
+}
+
+resource "azurerm_resource_group" "secondary" {
+  name     = "secondary"
+  location = "North Europe"
+}`,
+				"b.tf": `
+resource "azurerm_virtual_network" "example" {
+  name     = "example"
+  location = "westeurope"
+}
+
+resource "azurerm_virtual_network" "secondary" {
+  name     = "secondary"
+  location = "NorthEurope"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermLocationRule(),
+					Message: "`westeurope` is a programmatic name while most locations in the module are display names, use `West Europe`",
+				},
+				{
+					Rule:    NewAzurermLocationRule(),
+					Message: "`NorthEurope` is neither the programmatic name nor the display name of the region, use `North Europe`",
+				},
+			},
+			Fixed: map[string]string{
+				"b.tf": `
+resource "azurerm_virtual_network" "example" {
+  name     = "example"
+  location = "West Europe"
+}
+
+resource "azurerm_virtual_network" "secondary" {
+  name     = "secondary"
+  location = "North Europe"
+}`,
+			},
+		},
+		{
+			Name: "4. programmatic names preferred on a tie",
+			Files: map[string]string{
+				"main.tf": `
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "westeurope"
+}
+
+resource "azurerm_resource_group" "secondary" {
+  name     = "secondary"
+  location = "North Europe"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermLocationRule(),
+					Message: "`North Europe` is a display name while most locations in the module are programmatic names, use `northeurope`",
+				},
+			},
+			Fixed: map[string]string{
+				"main.tf": `
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "westeurope"
+}
+
+resource "azurerm_resource_group" "secondary" {
+  name     = "secondary"
+  location = "northeurope"
+}`,
+			},
+		},
+	}
+
+	rule := NewAzurermLocationRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, tc.Files)
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+			changes := runner.Changes()
+			for name, expected := range tc.Fixed {
+				if fixed := string(changes[name]); fixed != expected {
+					t.Fatalf("Expected fixed %s:\n%s\ngot:\n%s", name, expected, fixed)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.Rule = new(AzurermMissingRequiredArgumentRule)
+
+// AzurermMissingRequiredArgumentRule checks whether the required arguments and nested blocks are set
+type AzurermMissingRequiredArgumentRule struct {
+	tflint.DefaultRule
+}
+
+// NewAzurermMissingRequiredArgumentRule returns a new rule
+func NewAzurermMissingRequiredArgumentRule() *AzurermMissingRequiredArgumentRule {
+	return &AzurermMissingRequiredArgumentRule{}
+}
+
+func (r *AzurermMissingRequiredArgumentRule) Name() string {
+	return "azurerm_missing_required_argument"
+}
+
+func (r *AzurermMissingRequiredArgumentRule) Enabled() bool {
+	return true
+}
+
+func (r *AzurermMissingRequiredArgumentRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *AzurermMissingRequiredArgumentRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+func (r *AzurermMissingRequiredArgumentRule) Check(runner tflint.Runner) error {
+	return Check(runner, r.CheckFile)
+}
+
+// CheckFile checks the provider, resource and data source blocks in the file and their nested blocks for missing
+// required arguments and nested blocks
+func (r *AzurermMissingRequiredArgumentRule) CheckFile(runner tflint.Runner, file *hcl.File) error {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		logger.Debug("skip azurerm_missing_required_argument since it's not hcl file")
+		return nil
+	}
+	var issues []pendingIssue
+	var err error
+	for _, block := range azurermBlocks(body) {
+		issues = append(issues, r.checkBody([]string{block.Type, block.Labels[0]}, block.Body, block.DefRange())...)
+		subErr := BuildResourceBlock(block, file, nil).Walk(&BlockVisitor{
+			Block: func(path []string, nb *NestedBlock) error {
+				// a dynamic block without content generates nothing to check
+				if b := blockBody(nb.Block); b != nil {
+					issues = append(issues, r.checkBody(path, b, nb.DefRange())...)
+				}
+				return nil
+			},
+		})
+		if subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return emitIssues(runner, r, issues)
+}
+
+func (r *AzurermMissingRequiredArgumentRule) checkBody(path []string, body *hclsyntax.Body, rng hcl.Range) []pendingIssue {
+	schema := queryBlockSchema(path)
+	if schema == nil {
+		return nil
+	}
+	blocks := make(map[string]bool)
+	for _, nb := range body.Blocks {
+		if nb.Type != "dynamic" {
+			blocks[nb.Type] = true
+		} else if len(nb.Labels) > 0 {
+			blocks[nb.Labels[0]] = true
+		}
+	}
+	var missingArgs, missingBlocks []string
+	for name, attr := range schema.Attributes {
+		if !attr.Required {
+			continue
+		}
+		if _, ok := body.Attributes[name]; ok || attributeAsBlock(attr) && blocks[name] {
+			continue
+		}
+		missingArgs = append(missingArgs, name)
+	}
+	for name, nb := range schema.NestedBlocks {
+		if nb.MinItems > 0 && !blocks[name] {
+			missingBlocks = append(missingBlocks, name)
+		}
+	}
+	sort.Strings(missingArgs)
+	sort.Strings(missingBlocks)
+	var issues []pendingIssue
+	for _, name := range missingArgs {
+		issues = append(issues, pendingIssue{Message: missingMessage("argument", path, name), Range: rng})
+	}
+	for _, name := range missingBlocks {
+		issues = append(issues, pendingIssue{Message: missingMessage("block", path, name), Range: rng})
+	}
+	return issues
+}
+
+func missingMessage(kind string, path []string, name string) string {
+	return fmt.Sprintf("required %s `%s` of %s `%s` is not set", kind, strings.Join(argPath(path[2:], name), "."), blockKind(path[0]), path[1])
+}
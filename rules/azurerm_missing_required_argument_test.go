@@ -0,0 +1,133 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermMissingRequiredArgumentRule(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. required arguments set",
+			Content: `
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "westeurope"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. missing required arguments",
+			Content: `
+resource "azurerm_storage_account" "example" {
+  name                = "example"
+  resource_group_name = "example"
+  account_tier        = "Standard"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermMissingRequiredArgumentRule(),
+					Message: "required argument `account_replication_type` of resource `azurerm_storage_account` is not set",
+				},
+				{
+					Rule:    NewAzurermMissingRequiredArgumentRule(),
+					Message: "required argument `location` of resource `azurerm_storage_account` is not set",
+				},
+			},
+		},
+		{
+			Name: "3. missing required nested block and arguments in nested blocks",
+			Content: `
+resource "azurerm_kubernetes_cluster" "example" {
+  name                = "example"
+  location            = "westeurope"
+  resource_group_name = "example"
+
+  identity {}
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermMissingRequiredArgumentRule(),
+					Message: "required block `default_node_pool` of resource `azurerm_kubernetes_cluster` is not set",
+				},
+				{
+					Rule:    NewAzurermMissingRequiredArgumentRule(),
+					Message: "required argument `identity.type` of resource `azurerm_kubernetes_cluster` is not set",
+				},
+			},
+		},
+		{
+			Name: "4. dynamic blocks",
+			Content: `
+resource "azurerm_kubernetes_cluster" "example" {
+  name                = "example"
+  location            = "westeurope"
+  resource_group_name = "example"
+
+  dynamic "default_node_pool" {
+    for_each = var.node_pools
+
+    content {
+      name = default_node_pool.value.name
+    }
+  }
+  dynamic "identity" {
+    for_each = var.identity
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermMissingRequiredArgumentRule(),
+					Message: "required argument `default_node_pool.vm_size` of resource `azurerm_kubernetes_cluster` is not set",
+				},
+			},
+		},
+		{
+			Name: "5. provider and data source",
+			Content: `
+provider "azurerm" {
+  alias           = "secondary"
+  subscription_id = var.subscription_id
+}
+
+data "azurerm_resource_group" "example" {
+  provider = azurerm.secondary
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermMissingRequiredArgumentRule(),
+					Message: "required block `features` of provider `azurerm` is not set",
+				},
+				{
+					Rule:    NewAzurermMissingRequiredArgumentRule(),
+					Message: "required argument `name` of data source `azurerm_resource_group` is not set",
+				},
+			},
+		},
+		{
+			Name: "6. not azurerm block",
+			Content: `
+resource "azapi_resource" "example" {
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermMissingRequiredArgumentRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
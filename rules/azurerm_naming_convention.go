@@ -0,0 +1,256 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const (
+	abbreviationPlaceholder = "abbreviation"
+	// prefixPlaceholder is an alias of abbreviationPlaceholder, e.g. `{prefix}-{workload}-{env}-{region}`
+	prefixPlaceholder    = "prefix"
+	defaultNamingPattern = "{abbreviation}-{name}"
+)
+
+var namingPlaceholderRegex = regexp.MustCompile(`\{(\w+)\}`)
+
+var _ tflint.Rule = new(AzurermNamingConventionRule)
+
+// AzurermNamingConventionRule checks whether the names of the resources follow the naming convention of the
+// Cloud Adoption Framework, i.e. they carry the abbreviation of the resource type
+type AzurermNamingConventionRule struct {
+	tflint.DefaultRule
+}
+
+// azurermNamingConventionRuleConfig is the config of AzurermNamingConventionRule
+type azurermNamingConventionRuleConfig struct {
+	// Pattern is the naming pattern, e.g. `{prefix}-{workload}-{env}-{region}`
+	Pattern string `hclext:"pattern,optional"`
+	// Abbreviations overrides the bundled abbreviations by resource type, an empty abbreviation exempts the resource type
+	Abbreviations map[string]string `hclext:"abbreviations,optional"`
+	// Components lists the values allowed for the placeholders of the pattern, e.g. `env = ["dev", "prod"]`
+	Components map[string][]string `hclext:"components,optional"`
+}
+
+// namingToken is either a literal or a placeholder of the naming pattern
+type namingToken struct {
+	literal     string
+	placeholder string
+}
+
+// namingConvention is the parsed naming pattern along with the abbreviations and the allowed values of the placeholders
+type namingConvention struct {
+	tokens        []namingToken
+	abbreviations map[string]string
+	components    map[string][]string
+}
+
+// resourceNamingConvention is the naming convention applied to a resource type
+type resourceNamingConvention struct {
+	// display is the pattern with the abbreviation filled in, e.g. `rg-{workload}-{env}`
+	display string
+	regex   *regexp.Regexp
+	// prefix and suffix are the fixed leading and trailing parts of the names, e.g. `rg-`
+	prefix string
+	suffix string
+}
+
+// NewAzurermNamingConventionRule returns a new rule
+func NewAzurermNamingConventionRule() *AzurermNamingConventionRule {
+	return &AzurermNamingConventionRule{}
+}
+
+func (r *AzurermNamingConventionRule) Name() string {
+	return "azurerm_naming_convention"
+}
+
+func (r *AzurermNamingConventionRule) Enabled() bool {
+	return false
+}
+
+func (r *AzurermNamingConventionRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+func (r *AzurermNamingConventionRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+func (r *AzurermNamingConventionRule) Check(runner tflint.Runner) error {
+	convention, err := r.namingConvention(runner)
+	if err != nil {
+		return err
+	}
+	// conventions caches the naming conventions by resource type, nil for the resource types without abbreviation
+	conventions := make(map[string]*resourceNamingConvention)
+	return Check(runner, func(runner tflint.Runner, file *hcl.File) error {
+		return r.checkFile(runner, file, convention, conventions)
+	})
+}
+
+// namingConvention decodes the rule config and parses the naming pattern
+func (r *AzurermNamingConventionRule) namingConvention(runner tflint.Runner) (*namingConvention, error) {
+	config := azurermNamingConventionRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return nil, err
+	}
+	pattern := config.Pattern
+	if pattern == "" {
+		pattern = defaultNamingPattern
+	}
+	convention := &namingConvention{abbreviations: make(map[string]string), components: config.Components}
+	placeholders := make(map[string]bool)
+	last := 0
+	for _, loc := range namingPlaceholderRegex.FindAllStringSubmatchIndex(pattern, -1) {
+		if loc[0] > last {
+			convention.tokens = append(convention.tokens, namingToken{literal: pattern[last:loc[0]]})
+		}
+		name := pattern[loc[2]:loc[3]]
+		if name == prefixPlaceholder {
+			name = abbreviationPlaceholder
+		}
+		convention.tokens = append(convention.tokens, namingToken{placeholder: name})
+		placeholders[name] = true
+		last = loc[1]
+	}
+	if last < len(pattern) {
+		convention.tokens = append(convention.tokens, namingToken{literal: pattern[last:]})
+	}
+	for _, token := range convention.tokens {
+		if strings.ContainsAny(token.literal, "{}") {
+			return nil, fmt.Errorf("invalid pattern `%s` of rule `%s`: placeholders must be words in braces, e.g. `{env}`", pattern, r.Name())
+		}
+	}
+	if !placeholders[abbreviationPlaceholder] {
+		return nil, fmt.Errorf("invalid pattern `%s` of rule `%s`: `{%s}` or `{%s}` is missing", pattern, r.Name(), abbreviationPlaceholder, prefixPlaceholder)
+	}
+	for name, values := range config.Components {
+		if name == abbreviationPlaceholder || !placeholders[name] {
+			return nil, fmt.Errorf("invalid component `%s` of rule `%s`: not a placeholder of pattern `%s`", name, r.Name(), pattern)
+		}
+		if len(values) == 0 {
+			return nil, fmt.Errorf("invalid component `%s` of rule `%s`: no value is allowed", name, r.Name())
+		}
+	}
+	for resourceType, abbreviation := range cafAbbreviations {
+		convention.abbreviations[resourceType] = abbreviation
+	}
+	for resourceType, abbreviation := range config.Abbreviations {
+		// the resource types cannot be validated if no schema is close to the azurerm version of the module
+		if activeSchema != nil && queryBlockSchema([]string{"resource", resourceType}) == nil {
+			return nil, fmt.Errorf("invalid resource type `%s` in `abbreviations` of rule `%s`: not an azurerm resource", resourceType, r.Name())
+		}
+		convention.abbreviations[resourceType] = abbreviation
+	}
+	return convention, nil
+}
+
+// forResourceType returns the naming convention of a resource type, the literals of the pattern which are not allowed
+// in the names of the resource type, e.g. hyphens in the names of `azurerm_storage_account`, are dropped
+func (c *namingConvention) forResourceType(resourceType string) (*resourceNamingConvention, bool) {
+	abbreviation := c.abbreviations[resourceType]
+	if abbreviation == "" {
+		return nil, false
+	}
+	var charset *nameCharset
+	if constraint, ok := resourceNameConstraints[resourceType]["name"]; ok {
+		charset = &constraint.Charset
+	}
+	var display, regex strings.Builder
+	// parts are the texts of the tokens, nil for the placeholders other than the abbreviation
+	var parts []*string
+	for _, token := range c.tokens {
+		var text string
+		switch {
+		case token.placeholder == abbreviationPlaceholder:
+			text = abbreviation
+		case token.placeholder != "":
+			display.WriteString("{" + token.placeholder + "}")
+			parts = append(parts, nil)
+			if values, ok := c.components[token.placeholder]; ok {
+				var quoted []string
+				for _, value := range values {
+					quoted = append(quoted, regexp.QuoteMeta(value))
+				}
+				regex.WriteString("(?:" + strings.Join(quoted, "|") + ")")
+			} else {
+				regex.WriteString(".+")
+			}
+			continue
+		default:
+			for _, ch := range token.literal {
+				if charset == nil || charset.Char.MatchString(string(ch)) {
+					text += string(ch)
+				}
+			}
+		}
+		display.WriteString(text)
+		regex.WriteString(regexp.QuoteMeta(text))
+		parts = append(parts, &text)
+	}
+	convention := &resourceNamingConvention{
+		display: display.String(),
+		regex:   regexp.MustCompile("^" + regex.String() + "$"),
+	}
+	for i := 0; i < len(parts) && parts[i] != nil; i++ {
+		convention.prefix += *parts[i]
+	}
+	for i := len(parts) - 1; i >= 0 && parts[i] != nil; i-- {
+		convention.suffix = *parts[i] + convention.suffix
+	}
+	return convention, true
+}
+
+// matches checks whether the name matches the naming convention as far as it's known
+func (c *resourceNamingConvention) matches(bounds nameBounds) bool {
+	if bounds.known {
+		return c.regex.MatchString(bounds.value)
+	}
+	// the known part may be shorter than the fixed part, e.g. `"r${var.suffix}"`
+	prefixMatches := strings.HasPrefix(bounds.prefix, c.prefix) || strings.HasPrefix(c.prefix, bounds.prefix)
+	suffixMatches := strings.HasSuffix(bounds.suffix, c.suffix) || strings.HasSuffix(c.suffix, bounds.suffix)
+	return prefixMatches && suffixMatches
+}
+
+func (r *AzurermNamingConventionRule) checkFile(runner tflint.Runner, file *hcl.File, convention *namingConvention, conventions map[string]*resourceNamingConvention) error {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		logger.Debug("skip azurerm_naming_convention since it's not hcl file")
+		return nil
+	}
+	var issues []pendingIssue
+	for _, block := range body.Blocks {
+		if block.Type != "resource" || len(block.Labels) < 2 {
+			continue
+		}
+		name, ok := block.Body.Attributes["name"]
+		if !ok {
+			continue
+		}
+		c, cached := conventions[block.Labels[0]]
+		if !cached {
+			c, _ = convention.forResourceType(block.Labels[0])
+			conventions[block.Labels[0]] = c
+		}
+		if c == nil {
+			continue
+		}
+		bounds := evaluateNameBounds(runner, name.Expr)
+		if c.matches(bounds) {
+			continue
+		}
+		msg := fmt.Sprintf("`name` of resource `%s.%s` does not match the naming convention `%s`", block.Labels[0], block.Labels[1], c.display)
+		if bounds.known {
+			msg = fmt.Sprintf("%s, got `%s`", msg, bounds.value)
+		}
+		issues = append(issues, pendingIssue{Message: msg, Range: name.SrcRange})
+	}
+	return emitIssues(runner, r, issues)
+}
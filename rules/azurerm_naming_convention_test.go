@@ -0,0 +1,257 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermNamingConventionRule(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. abbreviation prefixes",
+			Files: map[string]string{
+				".tflint.hcl": `
+rule "azurerm_naming_convention" {
+  enabled = true
+}`,
+				"main.tf": `
+variable "workload" {
+  type = string
+}
+
+resource "azurerm_resource_group" "example" {
+  name = "rg-example"
+}
+
+resource "azurerm_virtual_network" "example" {
+  name = "vnet-${var.workload}"
+}
+
+resource "azurerm_storage_account" "example" {
+  name = "stexample"
+}
+
+resource "azurerm_key_vault" "example" {
+  name = "example-kv"
+}
+
+resource "azurerm_subnet" "example" {
+  name = "subnet-${var.workload}"
+}
+
+resource "azurerm_monitor_diagnostic_setting" "example" {
+  name = "example"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermNamingConventionRule(),
+					Message: "`name` of resource `azurerm_key_vault.example` does not match the naming convention `kv-{name}`, got `example-kv`",
+				},
+				{
+					Rule:    NewAzurermNamingConventionRule(),
+					Message: "`name` of resource `azurerm_subnet.example` does not match the naming convention `snet-{name}`",
+				},
+			},
+		},
+		{
+			Name: "2. configured pattern, components and abbreviations",
+			Files: map[string]string{
+				".tflint.hcl": `
+rule "azurerm_naming_convention" {
+  enabled = true
+  pattern = "{abbreviation}-{workload}-{env}-{region}"
+  abbreviations = {
+    azurerm_storage_account = "sa"
+    azurerm_key_vault       = ""
+  }
+  components = {
+    env = ["dev", "prod"]
+  }
+}`,
+				"main.tf": `
+variable "env" {
+  type = string
+}
+
+resource "azurerm_resource_group" "example" {
+  name = "rg-shop-prod-weu"
+}
+
+resource "azurerm_resource_group" "secondary" {
+  name = "rg-shop-staging-weu"
+}
+
+resource "azurerm_storage_account" "example" {
+  name = "sashop${var.env}weu"
+}
+
+resource "azurerm_storage_account" "secondary" {
+  name = "stshopprodweu"
+}
+
+resource "azurerm_key_vault" "example" {
+  name = "shop-prod-weu"
+}
+
+resource "azurerm_virtual_network" "example" {
+  name = format("vnet-shop-%s-weu", var.env)
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermNamingConventionRule(),
+					Message: "`name` of resource `azurerm_resource_group.secondary` does not match the naming convention `rg-{workload}-{env}-{region}`, got `rg-shop-staging-weu`",
+				},
+				{
+					Rule:    NewAzurermNamingConventionRule(),
+					Message: "`name` of resource `azurerm_storage_account.secondary` does not match the naming convention `sa{workload}{env}{region}`, got `stshopprodweu`",
+				},
+			},
+		},
+		{
+			Name: "3. abbreviation suffixes",
+			Files: map[string]string{
+				".tflint.hcl": `
+rule "azurerm_naming_convention" {
+  enabled = true
+  pattern = "{name}-{abbreviation}"
+}`,
+				"main.tf": `
+variable "workload" {
+  type = string
+}
+
+resource "azurerm_resource_group" "example" {
+  name = "${var.workload}-rg"
+}
+
+resource "azurerm_virtual_network" "example" {
+  name = "${var.workload}-network"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermNamingConventionRule(),
+					Message: "`name` of resource `azurerm_virtual_network.example` does not match the naming convention `{name}-vnet`",
+				},
+			},
+		},
+		{
+			Name: "4. prefix placeholder",
+			Files: map[string]string{
+				".tflint.hcl": `
+rule "azurerm_naming_convention" {
+  enabled = true
+  pattern = "{prefix}-{workload}-{env}-{region}"
+}`,
+				"main.tf": `
+resource "azurerm_resource_group" "example" {
+  name = "rg-shop-prod-weu"
+}
+
+resource "azurerm_virtual_network" "example" {
+  name = "shop-prod-weu"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermNamingConventionRule(),
+					Message: "`name` of resource `azurerm_virtual_network.example` does not match the naming convention `vnet-{workload}-{env}-{region}`, got `shop-prod-weu`",
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermNamingConventionRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, tc.Files)
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_AzurermNamingConventionRule_InvalidConfig(t *testing.T) {
+	configs := map[string]string{
+		"no abbreviation": `
+rule "azurerm_naming_convention" {
+  enabled = true
+  pattern = "{workload}-{env}"
+}`,
+		"unclosed placeholder": `
+rule "azurerm_naming_convention" {
+  enabled = true
+  pattern = "{abbreviation}-{env"
+}`,
+		"unknown component": `
+rule "azurerm_naming_convention" {
+  enabled = true
+  components = {
+    env = ["dev"]
+  }
+}`,
+		"unknown resource type": `
+rule "azurerm_naming_convention" {
+  enabled = true
+  abbreviations = {
+    azurerm_unknown = "unk"
+  }
+}`,
+	}
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{".tflint.hcl": config})
+			if err := NewAzurermNamingConventionRule().Check(runner); err == nil {
+				t.Fatal("Expected error for invalid config")
+			}
+		})
+	}
+}
+
+func Test_AzurermNamingConventionRule_NoSchema(t *testing.T) {
+	t.Cleanup(func() {
+		useAzurermSchema(bundledSchemas[0])
+	})
+	useAzurermSchema(nil)
+	runner := helper.TestRunner(t, map[string]string{
+		"main.tf": `
+resource "azurerm_storage_account" "example" {
+  name = "example"
+}`,
+		".tflint.hcl": `
+rule "azurerm_naming_convention" {
+  enabled = true
+  abbreviations = {
+    azurerm_storage_account = "sa"
+  }
+}`,
+	})
+	if err := NewAzurermNamingConventionRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermNamingConventionRule(),
+			Message: "`name` of resource `azurerm_storage_account.example` does not match the naming convention `sa{name}`, got `example`",
+		},
+	}, runner.Issues)
+}
+
+func Test_CafAbbreviations(t *testing.T) {
+	for resourceType := range cafAbbreviations {
+		if queryBlockSchema([]string{"resource", resourceType}) == nil {
+			t.Errorf("resource `%s` is not in the schema", resourceType)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.Rule = new(AzurermNestedBlockCardinalityRule)
+
+// AzurermNestedBlockCardinalityRule checks the number of nested blocks against their min items and max items
+type AzurermNestedBlockCardinalityRule struct {
+	tflint.DefaultRule
+}
+
+// NewAzurermNestedBlockCardinalityRule returns a new rule
+func NewAzurermNestedBlockCardinalityRule() *AzurermNestedBlockCardinalityRule {
+	return &AzurermNestedBlockCardinalityRule{}
+}
+
+func (r *AzurermNestedBlockCardinalityRule) Name() string {
+	return "azurerm_nested_block_cardinality"
+}
+
+func (r *AzurermNestedBlockCardinalityRule) Enabled() bool {
+	return true
+}
+
+func (r *AzurermNestedBlockCardinalityRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *AzurermNestedBlockCardinalityRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+func (r *AzurermNestedBlockCardinalityRule) Check(runner tflint.Runner) error {
+	return Check(runner, r.CheckFile)
+}
+
+// CheckFile checks the number of nested blocks in the provider, resource and data source blocks in the file
+func (r *AzurermNestedBlockCardinalityRule) CheckFile(runner tflint.Runner, file *hcl.File) error {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		logger.Debug("skip azurerm_nested_block_cardinality since it's not hcl file")
+		return nil
+	}
+	var issues []pendingIssue
+	var err error
+	for _, block := range azurermBlocks(body) {
+		issues = append(issues, r.checkBody([]string{block.Type, block.Labels[0]}, block.Body, block.DefRange())...)
+		subErr := BuildResourceBlock(block, file, nil).Walk(&BlockVisitor{
+			Block: func(path []string, nb *NestedBlock) error {
+				if b := blockBody(nb.Block); b != nil {
+					issues = append(issues, r.checkBody(path, b, nb.DefRange())...)
+				}
+				return nil
+			},
+		})
+		if subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return emitIssues(runner, r, issues)
+}
+
+// blockCount is the number of the nested blocks of a type in a body
+type blockCount struct {
+	// static are the blocks defined literally, and the dynamic blocks with a literal `for_each`
+	static []*hclsyntax.Block
+	count  int
+	// unknown is true if any dynamic block has a `for_each` which cannot be evaluated statically
+	unknown bool
+}
+
+func (r *AzurermNestedBlockCardinalityRule) checkBody(path []string, body *hclsyntax.Body, rng hcl.Range) []pendingIssue {
+	schema := queryBlockSchema(path)
+	if schema == nil {
+		return nil
+	}
+	counts := make(map[string]*blockCount)
+	for _, nb := range body.Blocks {
+		name := nb.Type
+		if nb.Type == "dynamic" {
+			if len(nb.Labels) == 0 {
+				continue
+			}
+			name = nb.Labels[0]
+		}
+		if _, ok := schema.NestedBlocks[name]; !ok {
+			continue
+		}
+		c, ok := counts[name]
+		if !ok {
+			c = &blockCount{}
+			counts[name] = c
+		}
+		if nb.Type != "dynamic" {
+			c.static = append(c.static, nb)
+			c.count++
+			continue
+		}
+		if n, ok := dynamicBlockCount(nb); ok {
+			c.static = append(c.static, nb)
+			c.count += n
+		} else {
+			c.unknown = true
+		}
+	}
+	var names []string
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var issues []pendingIssue
+	for _, name := range names {
+		c := counts[name]
+		nbSchema := schema.NestedBlocks[name]
+		subject := fmt.Sprintf("`%s` block(s) in %s `%s`", strings.Join(argPath(path[2:], name), "."), blockKind(path[0]), path[1])
+		// dynamic blocks with unknown `for_each` can only add blocks
+		if maxItems := maxBlockItems(nbSchema); maxItems > 0 && c.count > int(maxItems) {
+			issues = append(issues, pendingIssue{
+				Message: fmt.Sprintf("at most %d %s allowed, got %d", maxItems, subject, c.count),
+				Range:   c.static[len(c.static)-1].DefRange(),
+			})
+		}
+		// a nested block which is not set at all is reported by azurerm_missing_required_argument
+		if !c.unknown && c.count < int(nbSchema.MinItems) {
+			issues = append(issues, pendingIssue{
+				Message: fmt.Sprintf("at least %d %s required, got %d", nbSchema.MinItems, subject, c.count),
+				Range:   rng,
+			})
+		}
+	}
+	return issues
+}
+
+// maxBlockItems returns the max items of a nested block, 0 if unlimited
+func maxBlockItems(nb *tfjson.SchemaBlockType) uint64 {
+	if nb.NestingMode == tfjson.SchemaNestingModeSingle || nb.NestingMode == tfjson.SchemaNestingModeGroup {
+		return 1
+	}
+	return nb.MaxItems
+}
+
+// dynamicBlockCount returns the number of blocks generated by a dynamic block whose `for_each` is a literal collection
+func dynamicBlockCount(block *hclsyntax.Block) (int, bool) {
+	forEach, ok := block.Body.Attributes["for_each"]
+	if !ok {
+		return 0, false
+	}
+	val, ok := staticValue(forEach.Expr)
+	if !ok || val.IsNull() {
+		return 0, false
+	}
+	t := val.Type()
+	if t.IsObjectType() {
+		return len(t.AttributeTypes()), true
+	}
+	if t.IsCollectionType() || t.IsTupleType() {
+		return val.LengthInt(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,187 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermNestedBlockCardinalityRule(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. blocks within limits",
+			Content: `
+resource "azurerm_kubernetes_cluster" "example" {
+  name                = "example"
+  location            = "westeurope"
+  resource_group_name = "example"
+
+  default_node_pool {
+    name    = "default"
+    vm_size = "Standard_D2_v2"
+  }
+  identity {
+    type = "SystemAssigned"
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. too many blocks",
+			Content: `
+resource "azurerm_kubernetes_cluster" "example" {
+  name                = "example"
+  location            = "westeurope"
+  resource_group_name = "example"
+
+  default_node_pool {
+    name    = "default"
+    vm_size = "Standard_D2_v2"
+
+    upgrade_settings {
+      max_surge = "10%"
+    }
+    upgrade_settings {
+      max_surge = "20%"
+    }
+  }
+  identity {
+    type = "SystemAssigned"
+  }
+  identity {
+    type = "UserAssigned"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermNestedBlockCardinalityRule(),
+					Message: "at most 1 `default_node_pool.upgrade_settings` block(s) in resource `azurerm_kubernetes_cluster` allowed, got 2",
+				},
+				{
+					Rule:    NewAzurermNestedBlockCardinalityRule(),
+					Message: "at most 1 `identity` block(s) in resource `azurerm_kubernetes_cluster` allowed, got 2",
+				},
+			},
+		},
+		{
+			Name: "3. dynamic blocks with literal for_each",
+			Content: `
+resource "azurerm_kubernetes_cluster" "example" {
+  name                = "example"
+  location            = "westeurope"
+  resource_group_name = "example"
+
+  dynamic "default_node_pool" {
+    for_each = []
+
+    content {
+      name    = "default"
+      vm_size = "Standard_D2_v2"
+    }
+  }
+  dynamic "identity" {
+    for_each = { system = "SystemAssigned", user = "UserAssigned" }
+
+    content {
+      type = identity.value
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermNestedBlockCardinalityRule(),
+					Message: "at least 1 `default_node_pool` block(s) in resource `azurerm_kubernetes_cluster` required, got 0",
+				},
+				{
+					Rule:    NewAzurermNestedBlockCardinalityRule(),
+					Message: "at most 1 `identity` block(s) in resource `azurerm_kubernetes_cluster` allowed, got 2",
+				},
+			},
+		},
+		{
+			Name: "4. dynamic blocks with unknown for_each",
+			Content: `
+resource "azurerm_kubernetes_cluster" "example" {
+  name                = "example"
+  location            = "westeurope"
+  resource_group_name = "example"
+
+  dynamic "default_node_pool" {
+    for_each = var.node_pools
+
+    content {
+      name    = default_node_pool.value.name
+      vm_size = "Standard_D2_v2"
+    }
+  }
+  identity {
+    type = "SystemAssigned"
+  }
+  dynamic "identity" {
+    for_each = var.identity == null ? [] : [var.identity]
+
+    content {
+      type = identity.value.type
+    }
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "5. static blocks exceed the limit despite unknown dynamic blocks",
+			Content: `
+resource "azurerm_linux_virtual_machine" "example" {
+  os_disk {
+    caching = "ReadWrite"
+  }
+  os_disk {
+    caching = "None"
+  }
+  dynamic "os_disk" {
+    for_each = var.os_disks
+
+    content {
+      caching = os_disk.value.caching
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermNestedBlockCardinalityRule(),
+					Message: "at most 1 `os_disk` block(s) in resource `azurerm_linux_virtual_machine` allowed, got 2",
+				},
+			},
+		},
+		{
+			Name: "6. provider block",
+			Content: `
+provider "azurerm" {
+  features {}
+  features {}
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermNestedBlockCardinalityRule(),
+					Message: "at most 1 `features` block(s) in provider `azurerm` allowed, got 2",
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermNestedBlockCardinalityRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
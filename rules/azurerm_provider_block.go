@@ -0,0 +1,262 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const subscriptionIDEnvironmentVariable = "ARM_SUBSCRIPTION_ID"
+
+var subscriptionIDRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// providerSecretArguments are the secrets of the azurerm provider configuration and the environment variables which
+// may hold them instead. They are not marked as sensitive in the provider schema
+var providerSecretArguments = map[string]string{
+	"client_certificate":          "ARM_CLIENT_CERTIFICATE",
+	"client_certificate_password": "ARM_CLIENT_CERTIFICATE_PASSWORD",
+	"client_secret":               "ARM_CLIENT_SECRET",
+	"oidc_request_token":          "ARM_OIDC_REQUEST_TOKEN",
+	"oidc_token":                  "ARM_OIDC_TOKEN",
+}
+
+var _ tflint.Rule = new(AzurermProviderBlockRule)
+
+// AzurermProviderBlockRule checks the `provider "azurerm"` blocks and the references to the aliased azurerm providers
+type AzurermProviderBlockRule struct {
+	tflint.DefaultRule
+}
+
+// azurermProviderBlockRuleConfig is the config of AzurermProviderBlockRule
+type azurermProviderBlockRuleConfig struct {
+	// SubscriptionIDFromEnvironment accepts the provider blocks without `subscription_id`, which is read from
+	// `ARM_SUBSCRIPTION_ID` when Terraform runs
+	SubscriptionIDFromEnvironment bool `hclext:"subscription_id_from_environment,optional"`
+}
+
+// NewAzurermProviderBlockRule returns a new rule
+func NewAzurermProviderBlockRule() *AzurermProviderBlockRule {
+	return &AzurermProviderBlockRule{}
+}
+
+func (r *AzurermProviderBlockRule) Name() string {
+	return "azurerm_provider_block"
+}
+
+func (r *AzurermProviderBlockRule) Enabled() bool {
+	return true
+}
+
+func (r *AzurermProviderBlockRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *AzurermProviderBlockRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+func (r *AzurermProviderBlockRule) Check(runner tflint.Runner) error {
+	config := azurermProviderBlockRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+	locals, err := localAttributes(runner)
+	if err != nil {
+		return err
+	}
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	resolution, err := resolveAzurermSchema(runner)
+	if err != nil {
+		return err
+	}
+	// `subscription_id` is required since azurerm v4.0, which is only certain if the module rules out the older versions
+	requireSubscriptionID := resolution.sinceMajorVersion(4) && !config.SubscriptionIDFromEnvironment
+	aliases := azurermProviderAliases(files)
+	return Check(runner, func(runner tflint.Runner, file *hcl.File) error {
+		return r.checkFile(runner, file, requireSubscriptionID, locals, aliases)
+	})
+}
+
+func (r *AzurermProviderBlockRule) checkFile(runner tflint.Runner, file *hcl.File, requireSubscriptionID bool, locals map[string]*hclsyntax.Attribute, aliases []string) error {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		logger.Debug("skip azurerm_provider_block since it's not hcl file")
+		return nil
+	}
+	var issues []pendingIssue
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "provider":
+			if len(block.Labels) == 1 && block.Labels[0] == "azurerm" {
+				issues = append(issues, r.providerIssues(block, requireSubscriptionID, locals)...)
+			}
+		case "resource", "data", "ephemeral":
+			if attr, ok := block.Body.Attributes["provider"]; ok && len(block.Labels) == 2 {
+				subject := fmt.Sprintf("of %s `%s.%s`", blockKind(block.Type), block.Labels[0], block.Labels[1])
+				issues = append(issues, aliasIssues(attr.Expr, subject, aliases)...)
+			}
+		case "module":
+			attr, ok := block.Body.Attributes["providers"]
+			if !ok || len(block.Labels) != 1 {
+				continue
+			}
+			providers, ok := attr.Expr.(*hclsyntax.ObjectConsExpr)
+			if !ok {
+				continue
+			}
+			for _, item := range providers.Items {
+				issues = append(issues, aliasIssues(item.ValueExpr, fmt.Sprintf("passed to module `%s`", block.Labels[0]), aliases)...)
+			}
+		}
+	}
+	return emitIssues(runner, r, issues)
+}
+
+func (r *AzurermProviderBlockRule) providerIssues(block *hclsyntax.Block, requireSubscriptionID bool, locals map[string]*hclsyntax.Attribute) []pendingIssue {
+	address := "azurerm"
+	if attr, ok := block.Body.Attributes["alias"]; ok {
+		if val, ok := staticValue(attr.Expr); ok && val.Type() == cty.String && !val.IsNull() {
+			address = "azurerm." + val.AsString()
+		}
+	}
+	var issues []pendingIssue
+	if attr, ok := block.Body.Attributes["subscription_id"]; ok {
+		val, ok := staticValue(attr.Expr)
+		if ok && val.Type() == cty.String && !val.IsNull() && !subscriptionIDRegex.MatchString(val.AsString()) {
+			issues = append(issues, pendingIssue{
+				Message: fmt.Sprintf("`subscription_id` of provider `%s` must be a GUID, got `%s`", address, val.AsString()),
+				Range:   attr.Expr.Range(),
+			})
+		}
+	} else if requireSubscriptionID {
+		issues = append(issues, pendingIssue{
+			Message: fmt.Sprintf("`subscription_id` of provider `%s` is required since azurerm v4.0, set it or enable `subscription_id_from_environment` if `%s` is set where Terraform runs", address, subscriptionIDEnvironmentVariable),
+			Range:   block.DefRange(),
+		})
+	}
+	for _, name := range sortedKeys(providerSecretArguments) {
+		attr, ok := block.Body.Attributes[name]
+		if !ok {
+			continue
+		}
+		source, ok := hardcoded(attr.Expr, locals, make(map[string]bool))
+		if !ok {
+			continue
+		}
+		msg := fmt.Sprintf("secret `%s` of provider `%s` is hard-coded", name, address)
+		if source != "" {
+			msg = fmt.Sprintf("%s in `%s`", msg, source)
+		}
+		issues = append(issues, pendingIssue{
+			Message: fmt.Sprintf("%s, use a variable or the `%s` environment variable instead", msg, providerSecretArguments[name]),
+			Range:   attr.SrcRange,
+		})
+	}
+	return issues
+}
+
+// aliasIssues checks whether the aliased azurerm provider referenced by the expression, e.g. `azurerm.secondary`,
+// is declared
+func aliasIssues(expr hclsyntax.Expression, subject string, aliases []string) []pendingIssue {
+	traversal, diags := hcl.AbsTraversalForExpr(expr)
+	if diags.HasErrors() || len(traversal) != 2 || traversal.RootName() != "azurerm" {
+		return nil
+	}
+	alias := stepName(traversal[1])
+	for _, declared := range aliases {
+		if declared == alias {
+			return nil
+		}
+	}
+	msg := fmt.Sprintf("provider `azurerm.%s` %s is not declared", alias, subject)
+	if suggestions := suggestNames(alias, aliases); len(suggestions) > 0 {
+		msg = fmt.Sprintf("%s, did you mean `azurerm.%s`?", msg, strings.Join(suggestions, "` or `azurerm."))
+	}
+	return []pendingIssue{{Message: msg, Range: expr.Range()}}
+}
+
+// azurermProviderAliases returns the aliases of the azurerm provider declared by the `provider "azurerm"` blocks and
+// the `configuration_aliases` in `required_providers` of the module
+func azurermProviderAliases(files map[string]*hcl.File) []string {
+	var aliases []string
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			switch block.Type {
+			case "provider":
+				if len(block.Labels) != 1 || block.Labels[0] != "azurerm" {
+					continue
+				}
+				attr, ok := block.Body.Attributes["alias"]
+				if !ok {
+					continue
+				}
+				if val, ok := staticValue(attr.Expr); ok && val.Type() == cty.String && !val.IsNull() {
+					aliases = append(aliases, val.AsString())
+				}
+			case "terraform":
+				for _, nb := range block.Body.Blocks {
+					if nb.Type == "required_providers" {
+						aliases = append(aliases, configurationAliases(nb.Body)...)
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// configurationAliases returns the aliases in `configuration_aliases` of azurerm in the `required_providers` block,
+// e.g. `secondary` of `configuration_aliases = [azurerm.secondary]`
+func configurationAliases(body *hclsyntax.Body) []string {
+	attr, ok := body.Attributes["azurerm"]
+	if !ok {
+		return nil
+	}
+	requirement, ok := attr.Expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return nil
+	}
+	var aliases []string
+	for _, item := range requirement.Items {
+		if hcl.ExprAsKeyword(item.KeyExpr) != "configuration_aliases" {
+			continue
+		}
+		exprs, diags := hcl.ExprList(item.ValueExpr)
+		if diags.HasErrors() {
+			continue
+		}
+		for _, expr := range exprs {
+			traversal, diags := hcl.AbsTraversalForExpr(expr)
+			if diags.HasErrors() || len(traversal) != 2 || traversal.RootName() != "azurerm" {
+				continue
+			}
+			aliases = append(aliases, stepName(traversal[1]))
+		}
+	}
+	return aliases
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,212 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermProviderBlockRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. valid provider blocks",
+			Files: map[string]string{
+				"main.tf": `
+provider "azurerm" {
+  features {}
+  subscription_id = "00000000-0000-0000-0000-000000000000"
+  client_secret   = var.client_secret
+}
+
+provider "azurerm" {
+  alias           = "connectivity"
+  subscription_id = var.connectivity_subscription_id
+  features {}
+}
+
+resource "azurerm_virtual_network" "hub" {
+  provider = azurerm.connectivity
+}
+
+resource "azurerm_virtual_network" "spoke" {
+  provider = azurerm.management
+}
+
+module "spoke" {
+  source = "./spoke"
+  providers = {
+    azurerm     = azurerm
+    azurerm.hub = azurerm.connectivity
+  }
+}`,
+				"versions.tf": `
+terraform {
+  required_providers {
+    azurerm = {
+      source                = "hashicorp/azurerm"
+      configuration_aliases = [azurerm.management]
+    }
+  }
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. subscription and secrets",
+			Files: map[string]string{
+				"main.tf": `
+locals {
+  client_secret = "secret"
+}
+
+provider "azurerm" {
+  features {}
+  client_secret = local.client_secret
+}
+
+provider "azurerm" {
+  alias           = "connectivity"
+  subscription_id = "my-subscription"
+  client_secret   = "secret"
+  features {}
+}`,
+				"versions.tf": `
+terraform {
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 4.0"
+    }
+  }
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermProviderBlockRule(),
+					Message: "`subscription_id` of provider `azurerm` is required since azurerm v4.0, set it or enable `subscription_id_from_environment` if `ARM_SUBSCRIPTION_ID` is set where Terraform runs",
+				},
+				{
+					Rule:    NewAzurermProviderBlockRule(),
+					Message: "secret `client_secret` of provider `azurerm` is hard-coded in `local.client_secret`, use a variable or the `ARM_CLIENT_SECRET` environment variable instead",
+				},
+				{
+					Rule:    NewAzurermProviderBlockRule(),
+					Message: "`subscription_id` of provider `azurerm.connectivity` must be a GUID, got `my-subscription`",
+				},
+				{
+					Rule:    NewAzurermProviderBlockRule(),
+					Message: "secret `client_secret` of provider `azurerm.connectivity` is hard-coded, use a variable or the `ARM_CLIENT_SECRET` environment variable instead",
+				},
+			},
+		},
+		{
+			Name: "3. undeclared aliases",
+			Files: map[string]string{
+				"main.tf": `
+provider "azurerm" {
+  alias           = "connectivity"
+  subscription_id = var.connectivity_subscription_id
+  features {}
+}
+
+resource "azurerm_virtual_network" "hub" {
+  provider = azurerm.conectivity
+}
+
+data "azurerm_client_config" "management" {
+  provider = azurerm.management
+}
+
+module "spoke" {
+  source = "./spoke"
+  providers = {
+    azurerm.hub = azurerm.hub
+  }
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermProviderBlockRule(),
+					Message: "provider `azurerm.conectivity` of resource `azurerm_virtual_network.hub` is not declared, did you mean `azurerm.connectivity`?",
+				},
+				{
+					Rule:    NewAzurermProviderBlockRule(),
+					Message: "provider `azurerm.management` of data source `azurerm_client_config.management` is not declared",
+				},
+				{
+					Rule:    NewAzurermProviderBlockRule(),
+					Message: "provider `azurerm.hub` passed to module `spoke` is not declared",
+				},
+			},
+		},
+		{
+			Name: "4. subscription ID from the environment",
+			Files: map[string]string{
+				".tflint.hcl": `
+rule "azurerm_provider_block" {
+  enabled                          = true
+  subscription_id_from_environment = true
+}`,
+				"main.tf": `
+provider "azurerm" {
+  features {}
+}`,
+				"versions.tf": `
+terraform {
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 4.0"
+    }
+  }
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "5. azurerm versions without required subscription ID",
+			Files: map[string]string{
+				"main.tf": `
+provider "azurerm" {
+  features {}
+}`,
+				"versions.tf": `
+terraform {
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = ">= 3.0"
+    }
+  }
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "6. no azurerm version declared",
+			Files: map[string]string{
+				"main.tf": `
+provider "azurerm" {
+  features {}
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermProviderBlockRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, tc.Files)
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+const azurermFunctionPrefix = "provider::azurerm::"
+
+var _ tflint.Rule = new(AzurermProviderFunctionRule)
+
+// AzurermProviderFunctionRule checks the calls of azurerm provider-defined functions against their signatures
+type AzurermProviderFunctionRule struct {
+	tflint.DefaultRule
+}
+
+// NewAzurermProviderFunctionRule returns a new rule
+func NewAzurermProviderFunctionRule() *AzurermProviderFunctionRule {
+	return &AzurermProviderFunctionRule{}
+}
+
+func (r *AzurermProviderFunctionRule) Name() string {
+	return "azurerm_provider_function"
+}
+
+func (r *AzurermProviderFunctionRule) Enabled() bool {
+	return false
+}
+
+func (r *AzurermProviderFunctionRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *AzurermProviderFunctionRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+func (r *AzurermProviderFunctionRule) Check(runner tflint.Runner) error {
+	// without a schema close to the azurerm version used by the module, every function would be unknown
+	if activeSchema == nil {
+		return nil
+	}
+	return Check(runner, r.CheckFile)
+}
+
+// CheckFile checks the calls of azurerm provider-defined functions in the file
+func (r *AzurermProviderFunctionRule) CheckFile(runner tflint.Runner, file *hcl.File) error {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		logger.Debug("skip azurerm_provider_function since it's not hcl file")
+		return nil
+	}
+	var calls []*hclsyntax.FunctionCallExpr
+	_ = hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		if call, ok := node.(*hclsyntax.FunctionCallExpr); ok && strings.HasPrefix(call.Name, azurermFunctionPrefix) {
+			calls = append(calls, call)
+		}
+		return nil
+	})
+	// attributes are visited in random order
+	sort.Slice(calls, func(i, j int) bool {
+		return calls[i].Range().Start.Byte < calls[j].Range().Start.Byte
+	})
+	var err error
+	for _, call := range calls {
+		if subErr := r.checkCall(runner, call); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	return err
+}
+
+func (r *AzurermProviderFunctionRule) checkCall(runner tflint.Runner, call *hclsyntax.FunctionCallExpr) error {
+	signature := queryFunctionSignature(strings.TrimPrefix(call.Name, azurermFunctionPrefix))
+	if signature == nil {
+		return runner.EmitIssue(
+			r,
+			fmt.Sprintf("`%s` is not a function of the azurerm provider", call.Name),
+			call.NameRange,
+		)
+	}
+	if msg, ok := checkArity(signature, call); !ok {
+		return runner.EmitIssue(r, fmt.Sprintf("`%s` %s", call.Name, msg), call.Range())
+	}
+	var err error
+	for i, arg := range call.Args {
+		param := functionParameter(signature, i)
+		if param == nil || call.ExpandFinal && i == len(call.Args)-1 {
+			continue
+		}
+		val, ok := staticValue(arg)
+		if !ok {
+			continue
+		}
+		var msg string
+		if val.IsNull() {
+			if param.IsNullable {
+				continue
+			}
+			msg = "must not be null"
+		} else if _, convErr := convert.Convert(val, param.Type); convErr != nil {
+			msg = fmt.Sprintf("must be %s: %s", param.Type.FriendlyName(), convErr.Error())
+		} else {
+			continue
+		}
+		if subErr := runner.EmitIssue(
+			r,
+			fmt.Sprintf("argument `%s` of `%s` %s", param.Name, call.Name, msg),
+			arg.Range(),
+		); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	return err
+}
+
+// checkArity checks the number of arguments of the call, returns the issue message if it doesn't match the signature
+func checkArity(signature *tfjson.FunctionSignature, call *hclsyntax.FunctionCallExpr) (string, bool) {
+	want := len(signature.Parameters)
+	got := len(call.Args)
+	if call.ExpandFinal {
+		// the expanded argument may be any number of arguments
+		if signature.VariadicParameter == nil && got-1 > want {
+			return fmt.Sprintf("takes %d argument(s), got at least %d", want, got-1), false
+		}
+		return "", true
+	}
+	if got < want {
+		return fmt.Sprintf("takes %s%d argument(s), got %d", variadicHint(signature), want, got), false
+	}
+	if got > want && signature.VariadicParameter == nil {
+		return fmt.Sprintf("takes %d argument(s), got %d", want, got), false
+	}
+	return "", true
+}
+
+func variadicHint(signature *tfjson.FunctionSignature) string {
+	if signature.VariadicParameter != nil {
+		return "at least "
+	}
+	return ""
+}
+
+func functionParameter(signature *tfjson.FunctionSignature, i int) *tfjson.FunctionParameter {
+	if i < len(signature.Parameters) {
+		return signature.Parameters[i]
+	}
+	return signature.VariadicParameter
+}
+
+// staticValue evaluates an expression which doesn't reference anything or call any function
+func staticValue(expr hclsyntax.Expression) (cty.Value, bool) {
+	if len(expr.Variables()) > 0 {
+		return cty.NilVal, false
+	}
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || !val.IsWhollyKnown() {
+		return cty.NilVal, false
+	}
+	return val, true
+}
@@ -0,0 +1,89 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermProviderFunctionRule(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. valid calls",
+			Content: `
+locals {
+  subnet   = provider::azurerm::parse_resource_id(azurerm_subnet.example.id)
+  rg_name  = provider::azurerm::parse_resource_id("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg").resource_group_name
+  vnet_id  = provider::azurerm::normalise_resource_id(var.vnet_id)
+  expanded = provider::azurerm::normalise_resource_id(var.ids...)
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. unknown function",
+			Content: `
+locals {
+  id = provider::azurerm::parse_id(azurerm_subnet.example.id)
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermProviderFunctionRule(),
+					Message: "`provider::azurerm::parse_id` is not a function of the azurerm provider",
+				},
+			},
+		},
+		{
+			Name: "3. wrong arity",
+			Content: `
+locals {
+  id   = provider::azurerm::parse_resource_id(azurerm_subnet.example.id, "subnets")
+  none = provider::azurerm::normalise_resource_id()
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermProviderFunctionRule(),
+					Message: "`provider::azurerm::parse_resource_id` takes 1 argument(s), got 2",
+				},
+				{
+					Rule:    NewAzurermProviderFunctionRule(),
+					Message: "`provider::azurerm::normalise_resource_id` takes 1 argument(s), got 0",
+				},
+			},
+		},
+		{
+			Name: "4. wrong argument type",
+			Content: `
+resource "azurerm_role_assignment" "example" {
+  scope = provider::azurerm::normalise_resource_id(["/subscriptions/00000000-0000-0000-0000-000000000000"])
+  name  = provider::azurerm::normalise_resource_id(null)
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermProviderFunctionRule(),
+					Message: "argument `id` of `provider::azurerm::normalise_resource_id` must be string: string required",
+				},
+				{
+					Rule:    NewAzurermProviderFunctionRule(),
+					Message: "argument `id` of `provider::azurerm::normalise_resource_id` must not be null",
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermProviderFunctionRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var _ tflint.Rule = new(AzurermResourceIDFormatRule)
+
+// AzurermResourceIDFormatRule checks the literal resource IDs against the ARM resource types expected by the arguments
+type AzurermResourceIDFormatRule struct {
+	tflint.DefaultRule
+}
+
+// NewAzurermResourceIDFormatRule returns a new rule
+func NewAzurermResourceIDFormatRule() *AzurermResourceIDFormatRule {
+	return &AzurermResourceIDFormatRule{}
+}
+
+func (r *AzurermResourceIDFormatRule) Name() string {
+	return "azurerm_resource_id_format"
+}
+
+func (r *AzurermResourceIDFormatRule) Enabled() bool {
+	return true
+}
+
+func (r *AzurermResourceIDFormatRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *AzurermResourceIDFormatRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+func (r *AzurermResourceIDFormatRule) Check(runner tflint.Runner) error {
+	return Check(runner, r.CheckFile)
+}
+
+// CheckFile checks the literal resource IDs of the resource and data source blocks in the file
+func (r *AzurermResourceIDFormatRule) CheckFile(runner tflint.Runner, file *hcl.File) error {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		logger.Debug("skip azurerm_resource_id_format since it's not hcl file")
+		return nil
+	}
+	var issues []pendingIssue
+	visitor := &BlockVisitor{
+		Arg: func(path []string, arg *Arg) error {
+			armType, ok := expectedARMType(path)
+			if !ok || !isResourceIDArgument(path) {
+				return nil
+			}
+			subject := fmt.Sprintf("`%s` of %s `%s`", strings.Join(path[2:], "."), blockKind(path[0]), path[1])
+			// the elements of a list are checked one by one, since some of them may not be literals
+			exprs := []hclsyntax.Expression{arg.Expr}
+			if tuple, ok := arg.Expr.(*hclsyntax.TupleConsExpr); ok {
+				exprs = tuple.Exprs
+			}
+			for _, expr := range exprs {
+				val, ok := staticValue(expr)
+				if !ok || val.IsNull() || val.Type() != cty.String {
+					continue
+				}
+				if msg := resourceIDFormatMessage(val.AsString(), armType); msg != "" {
+					issues = append(issues, pendingIssue{Message: subject + " " + msg, Range: expr.Range()})
+				}
+			}
+			return nil
+		},
+	}
+	for _, block := range azurermBlocks(body) {
+		if err := BuildResourceBlock(block, file, nil).Walk(visitor); err != nil {
+			return err
+		}
+	}
+	return emitIssues(runner, r, issues)
+}
+
+// isResourceIDArgument checks whether the attribute at the path is a configurable string, or list or set of strings
+func isResourceIDArgument(path []string) bool {
+	parent := queryBlockSchema(path[:len(path)-1])
+	if parent == nil {
+		return false
+	}
+	attr, ok := parent.Attributes[path[len(path)-1]]
+	if !ok || isComputedOnly(attr) {
+		return false
+	}
+	t := attr.AttributeType
+	return t == cty.String || (t.IsListType() || t.IsSetType()) && t.ElementType() == cty.String
+}
+
+// resourceIDFormatMessage returns why the ID is not an ID of the ARM resource type, empty if it is
+func resourceIDFormatMessage(s, armType string) string {
+	id, err := parseResourceID(s)
+	if err != nil {
+		return fmt.Sprintf("is not a valid ID of `%s`: %s", armType, err.Error())
+	}
+	if !sameARMType(id.Type(), armType) {
+		return fmt.Sprintf("must be an ID of `%s`, got an ID of `%s`", armType, id.Type())
+	}
+	return ""
+}
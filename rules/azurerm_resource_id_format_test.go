@@ -0,0 +1,124 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermResourceIDFormatRule(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. valid resource IDs",
+			Content: `
+resource "azurerm_network_interface" "example" {
+  ip_configuration {
+    name      = "internal"
+    subnet_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/snet"
+  }
+}
+
+resource "azurerm_linux_virtual_machine" "example" {
+  network_interface_ids = [
+    azurerm_network_interface.example.id,
+    "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/rg/providers/microsoft.network/networkinterfaces/nic",
+  ]
+}
+
+resource "azurerm_mssql_database" "example" {
+  server_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Sql/servers/sql"
+}
+
+resource "azurerm_key_vault_secret" "example" {
+  key_vault_id = var.key_vault_id
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. IDs of other resource types",
+			Content: `
+resource "azurerm_network_interface" "example" {
+  ip_configuration {
+    name      = "internal"
+    subnet_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet"
+  }
+}
+
+resource "azurerm_linux_virtual_machine" "example" {
+  identity {
+    type         = "UserAssigned"
+    identity_ids = ["/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/kv"]
+  }
+}
+
+resource "azurerm_virtual_network" "example" {
+  ddos_protection_plan {
+    id     = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg"
+    enable = true
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceIDFormatRule(),
+					Message: "`ip_configuration.subnet_id` of resource `azurerm_network_interface` must be an ID of `Microsoft.Network/virtualNetworks/subnets`, got an ID of `Microsoft.Network/virtualNetworks`",
+				},
+				{
+					Rule:    NewAzurermResourceIDFormatRule(),
+					Message: "`identity.identity_ids` of resource `azurerm_linux_virtual_machine` must be an ID of `Microsoft.ManagedIdentity/userAssignedIdentities`, got an ID of `Microsoft.KeyVault/vaults`",
+				},
+				{
+					Rule:    NewAzurermResourceIDFormatRule(),
+					Message: "`ddos_protection_plan.id` of resource `azurerm_virtual_network` must be an ID of `Microsoft.Network/ddosProtectionPlans`, got an ID of `Microsoft.Resources/resourceGroups`",
+				},
+			},
+		},
+		{
+			Name: "3. malformed IDs",
+			Content: `
+resource "azurerm_key_vault_secret" "example" {
+  key_vault_id = "kv"
+}
+
+data "azurerm_key_vault_secret" "example" {
+  key_vault_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.KeyVault/vaults"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceIDFormatRule(),
+					Message: "`key_vault_id` of resource `azurerm_key_vault_secret` is not a valid ID of `Microsoft.KeyVault/vaults`: must start with `/`",
+				},
+				{
+					Rule:    NewAzurermResourceIDFormatRule(),
+					Message: "`key_vault_id` of data source `azurerm_key_vault_secret` is not a valid ID of `Microsoft.KeyVault/vaults`: `vaults` must be followed by a name",
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermResourceIDFormatRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_ResourceIDArgumentPaths(t *testing.T) {
+	for key := range resourceIDArgumentPaths {
+		path := append([]string{"resource"}, strings.Split(key, ".")...)
+		if !isResourceIDArgument(path) {
+			t.Errorf("`%s` is not a string argument of resource `%s`", strings.Join(path[2:], "."), path[1])
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package rules
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const parseResourceIDFunction = "parse_resource_id"
+
+var _ tflint.Rule = new(AzurermResourceIDParsingRule)
+
+// AzurermResourceIDParsingRule recommends `provider::azurerm::parse_resource_id` over splitting the IDs of azurerm
+// resources and data sources into segments
+type AzurermResourceIDParsingRule struct {
+	tflint.DefaultRule
+}
+
+// NewAzurermResourceIDParsingRule returns a new rule
+func NewAzurermResourceIDParsingRule() *AzurermResourceIDParsingRule {
+	return &AzurermResourceIDParsingRule{}
+}
+
+func (r *AzurermResourceIDParsingRule) Name() string {
+	return "azurerm_resource_id_parsing"
+}
+
+func (r *AzurermResourceIDParsingRule) Enabled() bool {
+	return true
+}
+
+func (r *AzurermResourceIDParsingRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+func (r *AzurermResourceIDParsingRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+func (r *AzurermResourceIDParsingRule) Check(runner tflint.Runner) error {
+	resolution, err := resolveAzurermSchema(runner)
+	if err != nil {
+		return err
+	}
+	// provider-defined functions are available since azurerm v4.0, so the splits are only fixed if the module doesn't
+	// allow an older version
+	fixable := resolution.sinceMajorVersion(4) && resolution.Schema.functions()[parseResourceIDFunction] != nil
+	locals, err := localAttributes(runner)
+	if err != nil {
+		return err
+	}
+	return Check(runner, func(runner tflint.Runner, file *hcl.File) error {
+		return r.checkFile(runner, file, locals, fixable)
+	})
+}
+
+func (r *AzurermResourceIDParsingRule) checkFile(runner tflint.Runner, file *hcl.File, locals map[string]*hclsyntax.Attribute, fixable bool) error {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		logger.Debug("skip azurerm_resource_id_parsing since it's not hcl file")
+		return nil
+	}
+	var splits []*idSplit
+	var extractions []*segmentExtraction
+	_ = hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		expr, ok := node.(hclsyntax.Expression)
+		if !ok {
+			return nil
+		}
+		if split, ok := resourceIDSplit(file, expr, locals); ok {
+			splits = append(splits, split)
+		}
+		if extraction, ok := extractSegment(file, expr, locals); ok {
+			extractions = append(extractions, extraction)
+		}
+		return nil
+	})
+	var issues []pendingIssue
+	// the splits in an extraction, e.g. `split("/", x.id)[length(split("/", x.id)) - 1]`, are reported once
+	extracted := make(map[*hclsyntax.FunctionCallExpr]bool)
+	for _, extraction := range extractions {
+		for _, call := range extraction.calls {
+			extracted[call] = true
+		}
+		issues = append(issues, r.extractionIssue(extraction, fixable))
+	}
+	for _, split := range splits {
+		if extracted[split.call] {
+			continue
+		}
+		issues = append(issues, pendingIssue{
+			Message: fmt.Sprintf("use `%s%s` instead of splitting the ID of `%s`", azurermFunctionPrefix, parseResourceIDFunction, split.ref.Address),
+			Range:   split.call.Range(),
+		})
+	}
+	return emitIssues(runner, r, issues)
+}
+
+func (r *AzurermResourceIDParsingRule) extractionIssue(extraction *segmentExtraction, fixable bool) pendingIssue {
+	split := extraction.split
+	attr, ok := parsedIDAttribute(armResourceTypes[split.ref.Type], extraction.segment, extraction.last)
+	if !ok {
+		return pendingIssue{
+			Message: fmt.Sprintf("use `%s%s` instead of splitting the ID of `%s`", azurermFunctionPrefix, parseResourceIDFunction, split.ref.Address),
+			Range:   extraction.expr.Range(),
+		}
+	}
+	replacement := fmt.Sprintf("%s%s(%s)%s", azurermFunctionPrefix, parseResourceIDFunction, split.id, attr)
+	rng := extraction.expr.Range()
+	issue := pendingIssue{
+		Message: fmt.Sprintf("use `%s` instead of splitting the ID of `%s`", replacement, split.ref.Address),
+		Range:   rng,
+	}
+	if fixable {
+		issue.Fix = func(f tflint.Fixer) error {
+			return f.ReplaceText(rng, replacement)
+		}
+	}
+	return issue
+}
+
+// idSplit is a `split("/", <id>)` call on the ID of an azurerm resource or data source
+type idSplit struct {
+	call *hclsyntax.FunctionCallExpr
+	ref  *idReference
+	// id is the source of the ID expression, e.g. `azurerm_subnet.example.id`
+	id string
+}
+
+func resourceIDSplit(file *hcl.File, expr hclsyntax.Expression, locals map[string]*hclsyntax.Attribute) (*idSplit, bool) {
+	call, ok := expr.(*hclsyntax.FunctionCallExpr)
+	if !ok || call.Name != "split" || len(call.Args) != 2 || call.ExpandFinal {
+		return nil, false
+	}
+	sep, ok := staticValue(call.Args[0])
+	if !ok || sep.Type() != cty.String || sep.IsNull() || sep.AsString() != "/" {
+		return nil, false
+	}
+	// the IDs of all instances are a list, which cannot be split
+	if _, ok := call.Args[1].(*hclsyntax.SplatExpr); ok {
+		return nil, false
+	}
+	ref, ok := resourceIDReference(call.Args[1], locals, make(map[string]bool))
+	if !ok {
+		return nil, false
+	}
+	return &idSplit{call: call, ref: ref, id: string(call.Args[1].Range().SliceBytes(file.Bytes))}, true
+}
+
+// segmentExtraction is an expression taking a segment of a split ID, e.g. `split("/", azurerm_subnet.example.id)[4]`
+type segmentExtraction struct {
+	expr  hclsyntax.Expression
+	split *idSplit
+	// segment is the index of the segment, unless last is true
+	segment int
+	last    bool
+	// calls are the `split` calls in the expression
+	calls []*hclsyntax.FunctionCallExpr
+}
+
+// extractSegment matches the expression with `split("/", <id>)[<n>]`, `element(split("/", <id>), <n>)`,
+// `reverse(split("/", <id>))[0]` and the last segment in the form of `split("/", <id>)[length(split("/", <id>)) - 1]`
+func extractSegment(file *hcl.File, expr hclsyntax.Expression, locals map[string]*hclsyntax.Attribute) (*segmentExtraction, bool) {
+	var collection, key hclsyntax.Expression
+	var literalKey cty.Value
+	switch e := expr.(type) {
+	case *hclsyntax.RelativeTraversalExpr:
+		// a literal index is parsed as a traversal
+		index, ok := e.Traversal[0].(hcl.TraverseIndex)
+		if !ok || len(e.Traversal) != 1 {
+			return nil, false
+		}
+		collection, literalKey = e.Source, index.Key
+	case *hclsyntax.IndexExpr:
+		collection, key = e.Collection, e.Key
+	case *hclsyntax.FunctionCallExpr:
+		if e.Name != "element" || len(e.Args) != 2 || e.ExpandFinal {
+			return nil, false
+		}
+		collection, key = e.Args[0], e.Args[1]
+	default:
+		return nil, false
+	}
+	reversed := false
+	if call, ok := collection.(*hclsyntax.FunctionCallExpr); ok && call.Name == "reverse" && len(call.Args) == 1 && !call.ExpandFinal {
+		collection, reversed = call.Args[0], true
+	}
+	split, ok := resourceIDSplit(file, collection, locals)
+	if !ok {
+		return nil, false
+	}
+	extraction := &segmentExtraction{expr: expr, split: split, calls: []*hclsyntax.FunctionCallExpr{split.call}}
+	if key != nil {
+		if val, ok := staticValue(key); ok {
+			literalKey = val
+		} else if lengthSplit, ok := lastIndexKey(file, key, locals); ok && lengthSplit.id == split.id && !reversed {
+			extraction.last = true
+			extraction.calls = append(extraction.calls, lengthSplit.call)
+			return extraction, true
+		}
+	}
+	segment, ok := segmentIndex(literalKey)
+	if !ok {
+		return nil, false
+	}
+	if reversed {
+		if segment != 0 {
+			return nil, false
+		}
+		extraction.last = true
+		return extraction, true
+	}
+	extraction.segment = segment
+	return extraction, true
+}
+
+// lastIndexKey matches the key with `length(split("/", <id>)) - 1`
+func lastIndexKey(file *hcl.File, key hclsyntax.Expression, locals map[string]*hclsyntax.Attribute) (*idSplit, bool) {
+	op, ok := key.(*hclsyntax.BinaryOpExpr)
+	if !ok || op.Op != hclsyntax.OpSubtract {
+		return nil, false
+	}
+	if one, ok := staticValue(op.RHS); !ok || one.Type() != cty.Number || one.IsNull() || !one.RawEquals(cty.NumberIntVal(1)) {
+		return nil, false
+	}
+	length, ok := op.LHS.(*hclsyntax.FunctionCallExpr)
+	if !ok || length.Name != "length" || len(length.Args) != 1 || length.ExpandFinal {
+		return nil, false
+	}
+	return resourceIDSplit(file, length.Args[0], locals)
+}
+
+func segmentIndex(key cty.Value) (int, bool) {
+	if key == cty.NilVal || key.IsNull() || !key.IsKnown() || key.Type() != cty.Number {
+		return 0, false
+	}
+	f := key.AsBigFloat()
+	if !f.IsInt() || f.Sign() < 0 || f.Cmp(big.NewFloat(1<<16)) > 0 {
+		return 0, false
+	}
+	i, _ := f.Int64()
+	return int(i), true
+}
+
+// parsedIDAttribute returns the traversal of the `parse_resource_id` result holding a segment of an ID of the ARM resource type,
+// e.g. `.resource_group_name` for the 5th segment of `/subscriptions/<id>/resourceGroups/<name>/providers/...`, false if it's a
+// constant segment or the ARM resource type is unknown
+func parsedIDAttribute(armType string, segment int, last bool) (string, bool) {
+	if armType == "" || sameARMType(armType, resourceGroupARMType) {
+		return "", false
+	}
+	types := strings.Split(armType, "/")[1:]
+	if last {
+		return ".resource_name", true
+	}
+	switch segment {
+	case 2:
+		return ".subscription_id", true
+	case 4:
+		return ".resource_group_name", true
+	case 6:
+		return ".resource_provider", true
+	}
+	if segment < 7 {
+		return "", false
+	}
+	i := (segment - 7) / 2
+	if i >= len(types) {
+		return "", false
+	}
+	if (segment-7)%2 == 0 {
+		// the types of the parents are constants
+		if len(types) != 1 {
+			return "", false
+		}
+		return ".resource_type", true
+	}
+	if i == len(types)-1 {
+		return ".resource_name", true
+	}
+	return fmt.Sprintf(".parent_resources[%q]", types[i]), true
+}
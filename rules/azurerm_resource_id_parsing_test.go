@@ -0,0 +1,180 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermResourceIDParsingRule(t *testing.T) {
+
+	versions := func(constraint string) string {
+		return fmt.Sprintf(`
+terraform {
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "%s"
+    }
+  }
+}`, constraint)
+	}
+	cases := []struct {
+		Name     string
+		Content  string
+		Versions string
+		Expected helper.Issues
+		Fixed    string
+	}{
+		{
+			Name: "1. no string manipulation of IDs",
+			Content: `
+locals {
+  resource_group_name = provider::azurerm::parse_resource_id(azurerm_subnet.example.id).resource_group_name
+  segments            = split("/", var.subnet_id)
+  names               = split(",", azurerm_subnet.example.id)
+}`,
+			Versions: versions(">= 4.0"),
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. segments of IDs",
+			Content: `
+locals {
+  subnet_id = azurerm_subnet.example.id
+}
+
+output "segments" {
+  value = {
+    subscription   = split("/", azurerm_virtual_network.example.id)[2]
+    resource_group = element(split("/", data.azurerm_subnet.example.id), 4)
+    network        = split("/", local.subnet_id)[8]
+    subnet         = split("/", azurerm_subnet.example.id)[length(split("/", azurerm_subnet.example.id)) - 1]
+    vault          = reverse(split("/", azurerm_key_vault.example.id))[0]
+    type           = split("/", azurerm_key_vault.example.id)[7]
+    providers      = split("/", azurerm_key_vault.example.id)[5]
+    segments       = split("/", azurerm_subnet.example["primary"].id)
+  }
+}`,
+			Versions: versions("~> 4.1, != 4.2.0"),
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceIDParsingRule(),
+					Message: "use `provider::azurerm::parse_resource_id(azurerm_virtual_network.example.id).subscription_id` instead of splitting the ID of `azurerm_virtual_network.example`",
+				},
+				{
+					Rule:    NewAzurermResourceIDParsingRule(),
+					Message: "use `provider::azurerm::parse_resource_id(data.azurerm_subnet.example.id).resource_group_name` instead of splitting the ID of `data.azurerm_subnet.example`",
+				},
+				{
+					Rule:    NewAzurermResourceIDParsingRule(),
+					Message: "use `provider::azurerm::parse_resource_id(local.subnet_id).parent_resources[\"virtualNetworks\"]` instead of splitting the ID of `azurerm_subnet.example`",
+				},
+				{
+					Rule:    NewAzurermResourceIDParsingRule(),
+					Message: "use `provider::azurerm::parse_resource_id(azurerm_subnet.example.id).resource_name` instead of splitting the ID of `azurerm_subnet.example`",
+				},
+				{
+					Rule:    NewAzurermResourceIDParsingRule(),
+					Message: "use `provider::azurerm::parse_resource_id(azurerm_key_vault.example.id).resource_name` instead of splitting the ID of `azurerm_key_vault.example`",
+				},
+				{
+					Rule:    NewAzurermResourceIDParsingRule(),
+					Message: "use `provider::azurerm::parse_resource_id(azurerm_key_vault.example.id).resource_type` instead of splitting the ID of `azurerm_key_vault.example`",
+				},
+				{
+					Rule:    NewAzurermResourceIDParsingRule(),
+					Message: "use `provider::azurerm::parse_resource_id` instead of splitting the ID of `azurerm_key_vault.example`",
+				},
+				{
+					Rule:    NewAzurermResourceIDParsingRule(),
+					Message: "use `provider::azurerm::parse_resource_id` instead of splitting the ID of `azurerm_subnet.example`",
+				},
+			},
+			Fixed: `
+locals {
+  subnet_id = azurerm_subnet.example.id
+}
+
+output "segments" {
+  value = {
+    subscription   = provider::azurerm::parse_resource_id(azurerm_virtual_network.example.id).subscription_id
+    resource_group = provider::azurerm::parse_resource_id(data.azurerm_subnet.example.id).resource_group_name
+    network        = provider::azurerm::parse_resource_id(local.subnet_id).parent_resources["virtualNetworks"]
+    subnet         = provider::azurerm::parse_resource_id(azurerm_subnet.example.id).resource_name
+    vault          = provider::azurerm::parse_resource_id(azurerm_key_vault.example.id).resource_name
+    type           = provider::azurerm::parse_resource_id(azurerm_key_vault.example.id).resource_type
+    providers      = split("/", azurerm_key_vault.example.id)[5]
+    segments       = split("/", azurerm_subnet.example["primary"].id)
+  }
+}`,
+		},
+		{
+			Name: "3. azurerm version without provider functions is not fixed",
+			Content: `
+output "resource_group" {
+  value = split("/", azurerm_subnet.example.id)[4]
+}`,
+			Versions: versions("~> 3.0"),
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceIDParsingRule(),
+					Message: "use `provider::azurerm::parse_resource_id(azurerm_subnet.example.id).resource_group_name` instead of splitting the ID of `azurerm_subnet.example`",
+				},
+			},
+		},
+		{
+			Name: "4. azurerm versions with and without provider functions are not fixed",
+			Content: `
+output "resource_group" {
+  value = split("/", azurerm_subnet.example.id)[4]
+}`,
+			Versions: versions(">= 3.0"),
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceIDParsingRule(),
+					Message: "use `provider::azurerm::parse_resource_id(azurerm_subnet.example.id).resource_group_name` instead of splitting the ID of `azurerm_subnet.example`",
+				},
+			},
+		},
+		{
+			Name: "5. no azurerm version declared is not fixed",
+			Content: `
+output "resource_group" {
+  value = split("/", azurerm_subnet.example.id)[4]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceIDParsingRule(),
+					Message: "use `provider::azurerm::parse_resource_id(azurerm_subnet.example.id).resource_group_name` instead of splitting the ID of `azurerm_subnet.example`",
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermResourceIDParsingRule()
+
+	for _, tc := range cases {
+		files := map[string]string{"config.tf": tc.Content}
+		if tc.Versions != "" {
+			files["versions.tf"] = tc.Versions
+		}
+		runner := helper.TestRunner(t, files)
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+			if tc.Fixed == "" {
+				if changes := runner.Changes(); len(changes) != 0 {
+					t.Fatalf("Expected no fix, got %d changed files", len(changes))
+				}
+				return
+			}
+			if fixed := string(runner.Changes()["config.tf"]); fixed != tc.Fixed {
+				t.Fatalf("Expected fixed config:\n%s\ngot:\n%s", tc.Fixed, fixed)
+			}
+		})
+	}
+}
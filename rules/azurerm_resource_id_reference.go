@@ -0,0 +1,155 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.Rule = new(AzurermResourceIDReferenceRule)
+
+// AzurermResourceIDReferenceRule checks whether the resources referenced by the arguments holding resource IDs are of
+// the ARM resource types expected by the arguments
+type AzurermResourceIDReferenceRule struct {
+	tflint.DefaultRule
+}
+
+// NewAzurermResourceIDReferenceRule returns a new rule
+func NewAzurermResourceIDReferenceRule() *AzurermResourceIDReferenceRule {
+	return &AzurermResourceIDReferenceRule{}
+}
+
+func (r *AzurermResourceIDReferenceRule) Name() string {
+	return "azurerm_resource_id_reference"
+}
+
+func (r *AzurermResourceIDReferenceRule) Enabled() bool {
+	return true
+}
+
+func (r *AzurermResourceIDReferenceRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *AzurermResourceIDReferenceRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+func (r *AzurermResourceIDReferenceRule) Check(runner tflint.Runner) error {
+	locals, err := localAttributes(runner)
+	if err != nil {
+		return err
+	}
+	return Check(runner, func(runner tflint.Runner, file *hcl.File) error {
+		return r.checkFile(runner, file, locals)
+	})
+}
+
+func (r *AzurermResourceIDReferenceRule) checkFile(runner tflint.Runner, file *hcl.File, locals map[string]*hclsyntax.Attribute) error {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		logger.Debug("skip azurerm_resource_id_reference since it's not hcl file")
+		return nil
+	}
+	var issues []pendingIssue
+	visitor := &BlockVisitor{
+		Arg: func(path []string, arg *Arg) error {
+			armType, ok := expectedARMType(path)
+			if !ok || !isResourceIDArgument(path) {
+				return nil
+			}
+			exprs := []hclsyntax.Expression{arg.Expr}
+			if tuple, ok := arg.Expr.(*hclsyntax.TupleConsExpr); ok {
+				exprs = tuple.Exprs
+			}
+			for _, expr := range exprs {
+				ref, ok := resourceIDReference(expr, locals, make(map[string]bool))
+				if !ok {
+					continue
+				}
+				refARMType, ok := armResourceTypes[ref.Type]
+				if !ok || sameARMType(refARMType, armType) {
+					continue
+				}
+				issues = append(issues, pendingIssue{
+					Message: fmt.Sprintf("`%s` of %s `%s` must be an ID of `%s`, but `%s` is a `%s`", strings.Join(path[2:], "."), blockKind(path[0]), path[1], armType, ref.Address, refARMType),
+					Range:   expr.Range(),
+				})
+			}
+			return nil
+		},
+	}
+	for _, block := range azurermBlocks(body) {
+		if err := BuildResourceBlock(block, file, nil).Walk(visitor); err != nil {
+			return err
+		}
+	}
+	return emitIssues(runner, r, issues)
+}
+
+// idReference is a resource or a data source whose `id` is referenced
+type idReference struct {
+	Type string
+	// Address is e.g. `azurerm_subnet.example` or `data.azurerm_subnet.example`
+	Address string
+}
+
+// resourceIDReference returns the resource or data source whose `id` is the value of the expression, which is
+// `<type>.<name>.id`, `<type>.<name>[<key>].id`, `<type>.<name>[*].id` or a local whose value is one of them
+func resourceIDReference(expr hclsyntax.Expression, locals map[string]*hclsyntax.Attribute, visited map[string]bool) (*idReference, bool) {
+	var traversal hcl.Traversal
+	switch e := expr.(type) {
+	case *hclsyntax.ScopeTraversalExpr:
+		traversal = e.Traversal
+	case *hclsyntax.SplatExpr:
+		source, ok := e.Source.(*hclsyntax.ScopeTraversalExpr)
+		each, isRelative := e.Each.(*hclsyntax.RelativeTraversalExpr)
+		if !ok || !isRelative {
+			return nil, false
+		}
+		if _, ok := each.Source.(*hclsyntax.AnonSymbolExpr); !ok {
+			return nil, false
+		}
+		traversal = append(source.Traversal[:len(source.Traversal):len(source.Traversal)], each.Traversal...)
+	default:
+		return nil, false
+	}
+	if traversal.RootName() == "local" && len(traversal) == 2 {
+		name := stepName(traversal[1])
+		local, ok := locals[name]
+		// visited guards against locals referencing each other
+		if !ok || visited[name] {
+			return nil, false
+		}
+		visited[name] = true
+		defer delete(visited, name)
+		return resourceIDReference(local.Expr, locals, visited)
+	}
+	var steps []string
+	for _, step := range traversal {
+		switch s := step.(type) {
+		case hcl.TraverseRoot:
+			steps = append(steps, s.Name)
+		case hcl.TraverseAttr:
+			steps = append(steps, s.Name)
+		case hcl.TraverseIndex, hcl.TraverseSplat:
+			// instances of resources with `count` or `for_each` are of the same type
+			continue
+		default:
+			return nil, false
+		}
+	}
+	prefix := ""
+	if steps[0] == "data" {
+		prefix, steps = "data.", steps[1:]
+	}
+	if len(steps) != 3 || steps[2] != "id" || !strings.HasPrefix(steps[0], "azurerm_") {
+		return nil, false
+	}
+	return &idReference{Type: steps[0], Address: prefix + steps[0] + "." + steps[1]}, true
+}
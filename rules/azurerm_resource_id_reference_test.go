@@ -0,0 +1,128 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermResourceIDReferenceRule(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. references of the expected types",
+			Content: `
+locals {
+  subnet_id = azurerm_subnet.example.id
+}
+
+resource "azurerm_network_interface" "example" {
+  ip_configuration {
+    name      = "internal"
+    subnet_id = local.subnet_id
+  }
+}
+
+resource "azurerm_network_interface" "secondary" {
+  ip_configuration {
+    name      = "internal"
+    subnet_id = data.azurerm_subnet.example.id
+  }
+}
+
+resource "azurerm_linux_virtual_machine" "example" {
+  network_interface_ids = [azurerm_network_interface.example.id, azurerm_network_interface.secondary.id]
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = azurerm_user_assigned_identity.example[*].id
+  }
+}
+
+resource "azurerm_key_vault_secret" "example" {
+  key_vault_id = azurerm_key_vault.example["primary"].id
+}
+
+resource "azurerm_mssql_database" "example" {
+  server_id = azurerm_mssql_server.example.id
+}
+
+resource "azurerm_role_assignment" "example" {
+  scope = azurerm_virtual_network.example.id
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. references of other types",
+			Content: `
+locals {
+  vnet_id = azurerm_virtual_network.example.id
+}
+
+resource "azurerm_network_interface" "example" {
+  ip_configuration {
+    name      = "internal"
+    subnet_id = azurerm_virtual_network.example.id
+  }
+}
+
+resource "azurerm_network_interface" "secondary" {
+  ip_configuration {
+    name      = "internal"
+    subnet_id = local.vnet_id
+  }
+}
+
+resource "azurerm_linux_virtual_machine" "example" {
+  network_interface_ids = [azurerm_network_interface.example.id, azurerm_public_ip.example.id]
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = data.azurerm_key_vault.example[*].id
+  }
+}
+
+resource "azurerm_key_vault_secret" "example" {
+  key_vault_id = azurerm_storage_account.example[0].id
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceIDReferenceRule(),
+					Message: "`ip_configuration.subnet_id` of resource `azurerm_network_interface` must be an ID of `Microsoft.Network/virtualNetworks/subnets`, but `azurerm_virtual_network.example` is a `Microsoft.Network/virtualNetworks`",
+				},
+				{
+					Rule:    NewAzurermResourceIDReferenceRule(),
+					Message: "`ip_configuration.subnet_id` of resource `azurerm_network_interface` must be an ID of `Microsoft.Network/virtualNetworks/subnets`, but `azurerm_virtual_network.example` is a `Microsoft.Network/virtualNetworks`",
+				},
+				{
+					Rule:    NewAzurermResourceIDReferenceRule(),
+					Message: "`network_interface_ids` of resource `azurerm_linux_virtual_machine` must be an ID of `Microsoft.Network/networkInterfaces`, but `azurerm_public_ip.example` is a `Microsoft.Network/publicIPAddresses`",
+				},
+				{
+					Rule:    NewAzurermResourceIDReferenceRule(),
+					Message: "`identity.identity_ids` of resource `azurerm_linux_virtual_machine` must be an ID of `Microsoft.ManagedIdentity/userAssignedIdentities`, but `data.azurerm_key_vault.example` is a `Microsoft.KeyVault/vaults`",
+				},
+				{
+					Rule:    NewAzurermResourceIDReferenceRule(),
+					Message: "`key_vault_id` of resource `azurerm_key_vault_secret` must be an ID of `Microsoft.KeyVault/vaults`, but `azurerm_storage_account.example` is a `Microsoft.Storage/storageAccounts`",
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermResourceIDReferenceRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
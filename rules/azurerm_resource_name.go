@@ -0,0 +1,285 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+var _ tflint.Rule = new(AzurermResourceNameRule)
+
+// AzurermResourceNameRule checks the names of the resources against the length and the characters allowed by Azure
+type AzurermResourceNameRule struct {
+	tflint.DefaultRule
+}
+
+// NewAzurermResourceNameRule returns a new rule
+func NewAzurermResourceNameRule() *AzurermResourceNameRule {
+	return &AzurermResourceNameRule{}
+}
+
+func (r *AzurermResourceNameRule) Name() string {
+	return "azurerm_resource_name"
+}
+
+func (r *AzurermResourceNameRule) Enabled() bool {
+	return true
+}
+
+func (r *AzurermResourceNameRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *AzurermResourceNameRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+func (r *AzurermResourceNameRule) Check(runner tflint.Runner) error {
+	return Check(runner, r.CheckFile)
+}
+
+// CheckFile checks the names of the resources in the file which have naming constraints
+func (r *AzurermResourceNameRule) CheckFile(runner tflint.Runner, file *hcl.File) error {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		logger.Debug("skip azurerm_resource_name since it's not hcl file")
+		return nil
+	}
+	var issues []pendingIssue
+	for _, block := range body.Blocks {
+		if block.Type != "resource" || len(block.Labels) < 2 {
+			continue
+		}
+		constraints, ok := resourceNameConstraints[block.Labels[0]]
+		if !ok {
+			continue
+		}
+		var names []string
+		for name := range constraints {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			attr, ok := block.Body.Attributes[name]
+			if !ok {
+				continue
+			}
+			subject := fmt.Sprintf("`%s` of resource `%s.%s`", name, block.Labels[0], block.Labels[1])
+			issues = append(issues, r.checkName(runner, attr, constraints[name], subject)...)
+		}
+		fallback, ok := computerNameFallbacks[block.Labels[0]]
+		if !ok {
+			continue
+		}
+		if _, ok := block.Body.Attributes[fallback]; ok {
+			continue
+		}
+		if attr, ok := block.Body.Attributes["name"]; ok {
+			subject := fmt.Sprintf("`name` of resource `%s.%s` used as `%s`", block.Labels[0], block.Labels[1], fallback)
+			issues = append(issues, r.checkName(runner, attr, constraints[fallback], subject)...)
+		}
+	}
+	return emitIssues(runner, r, issues)
+}
+
+func (r *AzurermResourceNameRule) checkName(runner tflint.Runner, attr *hclsyntax.Attribute, constraint *nameConstraint, subject string) []pendingIssue {
+	bounds := evaluateNameBounds(runner, attr.Expr)
+	allowed := fmt.Sprintf("must be %d to %d characters long", constraint.Min, constraint.Max)
+	var messages []string
+	switch {
+	case bounds.known && (bounds.min < constraint.Min || bounds.min > constraint.Max):
+		messages = append(messages, fmt.Sprintf("%s %s, got %d", subject, allowed, bounds.min))
+	case bounds.min > constraint.Max:
+		messages = append(messages, fmt.Sprintf("%s is at least %d characters long, %s", subject, bounds.min, allowed))
+	case bounds.max >= 0 && bounds.max < constraint.Min:
+		messages = append(messages, fmt.Sprintf("%s is at most %d characters long, %s", subject, bounds.max, allowed))
+	}
+	invalid := invalidNameChar(bounds.fragments, constraint.Charset)
+	if invalid != "" {
+		messages = append(messages, fmt.Sprintf("%s contains `%s`, only %s are allowed", subject, invalid, constraint.Charset.Description))
+	}
+	if bounds.known && invalid == "" && constraint.Pattern != nil && !constraint.Pattern.MatchString(bounds.value) {
+		messages = append(messages, fmt.Sprintf("%s %s, got `%s`", subject, constraint.PatternDescription, bounds.value))
+	}
+	var issues []pendingIssue
+	for _, msg := range messages {
+		issues = append(issues, pendingIssue{Message: msg, Range: attr.SrcRange})
+	}
+	return issues
+}
+
+// invalidNameChar returns the first character of the fragments which is not in the charset
+func invalidNameChar(fragments []string, charset nameCharset) string {
+	for _, fragment := range fragments {
+		for _, c := range fragment {
+			if !charset.Char.MatchString(string(c)) {
+				return string(c)
+			}
+		}
+	}
+	return ""
+}
+
+// nameBounds is what is known about a name which may not be evaluated as a whole,
+// e.g. `"st${var.env}"` is at least 2 characters long and contains `st`
+type nameBounds struct {
+	min int
+	// max is -1 if the length is unbounded
+	max int
+	// fragments are the parts of the name which are known
+	fragments []string
+	// prefix and suffix are the known leading and trailing parts of the name
+	prefix string
+	suffix string
+	// value is the name if it's known
+	value string
+	known bool
+}
+
+func exactNameBounds(value string) nameBounds {
+	n := utf8.RuneCountInString(value)
+	return nameBounds{min: n, max: n, fragments: []string{value}, prefix: value, suffix: value, value: value, known: true}
+}
+
+var unknownNameBounds = nameBounds{max: -1}
+
+func concatNameBounds(parts []nameBounds) nameBounds {
+	result := exactNameBounds("")
+	result.fragments = nil
+	for _, part := range parts {
+		result.min += part.min
+		if result.max < 0 || part.max < 0 {
+			result.max = -1
+		} else {
+			result.max += part.max
+		}
+		result.fragments = append(result.fragments, part.fragments...)
+		if result.known {
+			result.prefix += part.prefix
+		}
+		result.value += part.value
+		result.known = result.known && part.known
+	}
+	for i := len(parts) - 1; i >= 0; i-- {
+		result.suffix = parts[i].suffix + result.suffix
+		if !parts[i].known {
+			break
+		}
+	}
+	if !result.known {
+		result.value = ""
+	}
+	return result
+}
+
+// evaluateNameBounds evaluates the name, or computes its bounds from the parts of the templates and `format()` calls
+// which can be evaluated
+func evaluateNameBounds(runner tflint.Runner, expr hclsyntax.Expression) nameBounds {
+	if value, ok := evaluateString(runner, expr); ok {
+		return exactNameBounds(value)
+	}
+	switch e := expr.(type) {
+	case *hclsyntax.TemplateWrapExpr:
+		return evaluateNameBounds(runner, e.Wrapped)
+	case *hclsyntax.TemplateExpr:
+		var parts []nameBounds
+		for _, part := range e.Parts {
+			parts = append(parts, evaluateNameBounds(runner, part))
+		}
+		return concatNameBounds(parts)
+	case *hclsyntax.FunctionCallExpr:
+		if e.ExpandFinal || len(e.Args) == 0 {
+			return unknownNameBounds
+		}
+		switch e.Name {
+		case "format":
+			return formatNameBounds(runner, e.Args)
+		case "lower", "upper":
+			bounds := evaluateNameBounds(runner, e.Args[0])
+			transform := strings.ToLower
+			if e.Name == "upper" {
+				transform = strings.ToUpper
+			}
+			for i, fragment := range bounds.fragments {
+				bounds.fragments[i] = transform(fragment)
+			}
+			bounds.prefix, bounds.suffix, bounds.value = transform(bounds.prefix), transform(bounds.suffix), transform(bounds.value)
+			return bounds
+		case "substr":
+			// the known parts of the string may be cut off
+			if len(e.Args) != 3 {
+				return unknownNameBounds
+			}
+			var length int
+			val, ok := staticValue(e.Args[2])
+			if !ok || val.Type() != cty.Number || gocty.FromCtyValue(val, &length) != nil || length < 0 {
+				return unknownNameBounds
+			}
+			max := evaluateNameBounds(runner, e.Args[0]).max
+			if max < 0 || max > length {
+				max = length
+			}
+			return nameBounds{max: max}
+		}
+	}
+	return unknownNameBounds
+}
+
+// formatNameBounds computes the bounds of `format()` calls whose format is a literal with `%s`, `%d` and `%v` verbs only
+func formatNameBounds(runner tflint.Runner, args []hclsyntax.Expression) nameBounds {
+	val, ok := staticValue(args[0])
+	if !ok || val.Type() != cty.String {
+		return unknownNameBounds
+	}
+	format := []rune(val.AsString())
+	var parts []nameBounds
+	var literal strings.Builder
+	next := 1
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			literal.WriteRune(format[i])
+			continue
+		}
+		if i+1 >= len(format) {
+			return unknownNameBounds
+		}
+		i++
+		if format[i] == '%' {
+			literal.WriteRune('%')
+			continue
+		}
+		if !strings.ContainsRune("sdv", format[i]) || next >= len(args) {
+			return unknownNameBounds
+		}
+		parts = append(parts, exactNameBounds(literal.String()), evaluateNameBounds(runner, args[next]))
+		literal.Reset()
+		next++
+	}
+	return concatNameBounds(append(parts, exactNameBounds(literal.String())))
+}
+
+// evaluateString evaluates the expression to a string, either statically or with the variables of the module
+func evaluateString(runner tflint.Runner, expr hclsyntax.Expression) (string, bool) {
+	val, ok := staticValue(expr)
+	if !ok && runner.EvaluateExpr(expr, &val, nil) != nil {
+		return "", false
+	}
+	if val.IsNull() || !val.IsWhollyKnown() || val.IsMarked() {
+		return "", false
+	}
+	val, err := convert.Convert(val, cty.String)
+	if err != nil {
+		return "", false
+	}
+	return val.AsString(), true
+}
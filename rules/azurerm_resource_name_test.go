@@ -0,0 +1,165 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermResourceNameRule(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. valid names",
+			Content: `
+variable "env" {
+  type = string
+}
+
+resource "azurerm_storage_account" "example" {
+  name = "stexample${var.env}"
+}
+
+resource "azurerm_key_vault" "example" {
+  name = format("kv-%s-%s", var.env, "weu")
+}
+
+resource "azurerm_windows_virtual_machine" "example" {
+  name          = "vm-example-westeurope-001"
+  computer_name = "vmexample001"
+}
+
+resource "azurerm_resource_group" "example" {
+  name = "rg-example"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. literal names",
+			Content: `
+variable "name" {
+  default = "Example_Storage"
+}
+
+resource "azurerm_storage_account" "example" {
+  name = "st"
+}
+
+resource "azurerm_storage_account" "secondary" {
+  name = var.name
+}
+
+resource "azurerm_key_vault" "example" {
+  name = "1-kv--example"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceNameRule(),
+					Message: "`name` of resource `azurerm_storage_account.example` must be 3 to 24 characters long, got 2",
+				},
+				{
+					Rule:    NewAzurermResourceNameRule(),
+					Message: "`name` of resource `azurerm_storage_account.secondary` contains `E`, only lowercase letters and numbers are allowed",
+				},
+				{
+					Rule:    NewAzurermResourceNameRule(),
+					Message: "`name` of resource `azurerm_key_vault.example` must start with a letter, end with a letter or a number, and not contain consecutive hyphens, got `1-kv--example`",
+				},
+			},
+		},
+		{
+			Name: "3. bounds of interpolated names",
+			Content: `
+variable "env" {
+  type = string
+}
+
+resource "azurerm_storage_account" "example" {
+  name = "st-${var.env}"
+}
+
+resource "azurerm_storage_account" "secondary" {
+  name = lower(format("stexample%sdiagnostics%s", var.env, "westeurope"))
+}
+
+resource "azurerm_storage_account" "tertiary" {
+  name = substr(var.env, 0, 2)
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceNameRule(),
+					Message: "`name` of resource `azurerm_storage_account.example` contains `-`, only lowercase letters and numbers are allowed",
+				},
+				{
+					Rule:    NewAzurermResourceNameRule(),
+					Message: "`name` of resource `azurerm_storage_account.secondary` is at least 30 characters long, must be 3 to 24 characters long",
+				},
+				{
+					Rule:    NewAzurermResourceNameRule(),
+					Message: "`name` of resource `azurerm_storage_account.tertiary` is at most 2 characters long, must be 3 to 24 characters long",
+				},
+			},
+		},
+		{
+			Name: "4. computer name of windows virtual machines",
+			Content: `
+resource "azurerm_windows_virtual_machine" "example" {
+  name = "vm-example-westeurope"
+}
+
+resource "azurerm_windows_virtual_machine" "secondary" {
+  name          = "vm-example-westeurope"
+  computer_name = "vm-example-westeurope"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceNameRule(),
+					Message: "`name` of resource `azurerm_windows_virtual_machine.example` used as `computer_name` must be 1 to 15 characters long, got 21",
+				},
+				{
+					Rule:    NewAzurermResourceNameRule(),
+					Message: "`computer_name` of resource `azurerm_windows_virtual_machine.secondary` must be 1 to 15 characters long, got 21",
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermResourceNameRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_ResourceNameConstraints(t *testing.T) {
+	for resourceType, constraints := range resourceNameConstraints {
+		schema := queryBlockSchema([]string{"resource", resourceType})
+		if schema == nil {
+			t.Errorf("resource `%s` is not in the schema", resourceType)
+			continue
+		}
+		for name, constraint := range constraints {
+			if _, ok := schema.Attributes[name]; !ok {
+				t.Errorf("`%s` is not an argument of resource `%s`", name, resourceType)
+			}
+			if constraint.Min < 1 || constraint.Max < constraint.Min {
+				t.Errorf("invalid length of `%s` of resource `%s`", name, resourceType)
+			}
+		}
+	}
+	for resourceType, fallback := range computerNameFallbacks {
+		if _, ok := resourceNameConstraints[resourceType][fallback]; !ok {
+			t.Errorf("no constraint of `%s` of resource `%s`", fallback, resourceType)
+		}
+	}
+}
@@ -2,23 +2,33 @@ package rules
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/terraform-linters/tflint-plugin-sdk/logger"
 
 	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
-	"github.com/lonegunmanb/terraform-azurerm-schema/v4/generated"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
 )
 
 var _ tflint.Rule = new(AzurermResourceTagRule)
 
-// AzurermResourceTagRule checks whether the tags arg is specified if supported
+// AzurermResourceTagRule checks whether the tags arg is specified if supported,
+// and whether it carries the tags of the resource group the resource belongs to
 type AzurermResourceTagRule struct {
 	tflint.DefaultRule
 }
 
+// azurermResourceTagRuleConfig is the config of AzurermResourceTagRule
+type azurermResourceTagRuleConfig struct {
+	// TagSources lists the locals or variables, e.g. `local.tags`, from which the tags of every resource must derive
+	TagSources []string `hclext:"tag_sources,optional"`
+}
+
 func (r *AzurermResourceTagRule) Name() string {
 	return "azurerm_resource_tag"
 }
@@ -36,7 +46,17 @@ func (r *AzurermResourceTagRule) Link() string {
 }
 
 func (r *AzurermResourceTagRule) Check(runner tflint.Runner) error {
-	return Check(runner, r.CheckFile)
+	tagSources, err := r.tagSources(runner)
+	if err != nil {
+		return err
+	}
+	resourceGroups, err := resourceGroupBlocks(runner)
+	if err != nil {
+		return err
+	}
+	return Check(runner, func(runner tflint.Runner, file *hcl.File) error {
+		return r.checkFile(runner, file, resourceGroups, tagSources)
+	})
 }
 
 // NewAzurermResourceTagRule returns a new rule
@@ -46,6 +66,38 @@ func NewAzurermResourceTagRule() *AzurermResourceTagRule {
 
 // CheckFile checks whether the tags arg is specified if supported
 func (r *AzurermResourceTagRule) CheckFile(runner tflint.Runner, file *hcl.File) error {
+	tagSources, err := r.tagSources(runner)
+	if err != nil {
+		return err
+	}
+	resourceGroups, err := resourceGroupBlocks(runner)
+	if err != nil {
+		return err
+	}
+	return r.checkFile(runner, file, resourceGroups, tagSources)
+}
+
+// tagSources decodes the rule config and parses the configured tag sources
+func (r *AzurermResourceTagRule) tagSources(runner tflint.Runner) ([]hcl.Traversal, error) {
+	config := azurermResourceTagRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return nil, err
+	}
+	var sources []hcl.Traversal
+	for _, source := range config.TagSources {
+		traversal, diags := hclsyntax.ParseTraversalAbs([]byte(source), "", hcl.InitialPos)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("invalid tag source `%s` of rule `%s`: %s", source, r.Name(), diags.Error())
+		}
+		if root := traversal.RootName(); root != "local" && root != "var" {
+			return nil, fmt.Errorf("invalid tag source `%s` of rule `%s`: must be a local or a variable", source, r.Name())
+		}
+		sources = append(sources, traversal)
+	}
+	return sources, nil
+}
+
+func (r *AzurermResourceTagRule) checkFile(runner tflint.Runner, file *hcl.File, resourceGroups map[string]*hclsyntax.Block, tagSources []hcl.Traversal) error {
 	body, ok := file.Body.(*hclsyntax.Body)
 	if !ok {
 		logger.Debug("skip azurerm_resource_tag since it's not hcl file")
@@ -56,8 +108,8 @@ func (r *AzurermResourceTagRule) CheckFile(runner tflint.Runner, file *hcl.File)
 	for _, block := range blocks {
 		var subErr error
 		switch block.Type {
-		case "resource":
-			subErr = r.visitAzResource(runner, block)
+		case "resource", "ephemeral":
+			subErr = r.visitAzResource(runner, block, resourceGroups, tagSources)
 		}
 		if subErr != nil {
 			err = multierror.Append(err, subErr)
@@ -66,19 +118,252 @@ func (r *AzurermResourceTagRule) CheckFile(runner tflint.Runner, file *hcl.File)
 	return err
 }
 
-func (r *AzurermResourceTagRule) visitAzResource(runner tflint.Runner, azBlock *hclsyntax.Block) error {
-	resourceSchema, isAzureResource := generated.Resources[azBlock.Labels[0]]
-	if !isAzureResource {
+func (r *AzurermResourceTagRule) visitAzResource(runner tflint.Runner, azBlock *hclsyntax.Block, resourceGroups map[string]*hclsyntax.Block, tagSources []hcl.Traversal) error {
+	resourceSchema := queryBlockSchema([]string{azBlock.Type, azBlock.Labels[0]})
+	if resourceSchema == nil {
 		return nil
 	}
-	_, isTagSupported := resourceSchema.Block.Attributes["tags"]
-	_, isTagSet := azBlock.Body.Attributes["tags"]
-	if isTagSupported && !isTagSet {
+	_, isTagSupported := resourceSchema.Attributes["tags"]
+	if !isTagSupported {
+		return nil
+	}
+	tags, isTagSet := azBlock.Body.Attributes["tags"]
+	if !isTagSet {
 		return runner.EmitIssue(
 			r,
 			fmt.Sprintf("`tags` argument is not set but supported in resource `%s`", azBlock.Labels[0]),
 			azBlock.DefRange(),
 		)
 	}
-	return nil
+	if len(tagSources) > 0 && !derivesFrom(tags.Expr, tagSources) {
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("`tags` argument of resource `%s.%s` should derive from %s",
+				azBlock.Labels[0], azBlock.Labels[1], traversalsString(tagSources)),
+			tags.SrcRange,
+		); err != nil {
+			return err
+		}
+	}
+	return r.checkInheritedTags(runner, azBlock, tags, resourceGroups)
+}
+
+// derivesFrom checks whether the expression references any of the sources, or an element of them
+func derivesFrom(expr hcl.Expression, sources []hcl.Traversal) bool {
+	for _, traversal := range expr.Variables() {
+		for _, source := range sources {
+			if traversalHasPrefix(traversal, source) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// traversalHasPrefix checks whether the traversal starts with all steps of the prefix
+func traversalHasPrefix(traversal, prefix hcl.Traversal) bool {
+	if len(traversal) < len(prefix) {
+		return false
+	}
+	for i, step := range prefix {
+		if !sameStep(step, traversal[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameStep compares traversal steps by their names or index keys, `x.a` and `x["a"]` are the same step,
+// while an unknown key matches no step
+func sameStep(a, b hcl.Traverser) bool {
+	aIndex, aIsIndex := a.(hcl.TraverseIndex)
+	bIndex, bIsIndex := b.(hcl.TraverseIndex)
+	if aIsIndex && aIndex.Key.Type() != cty.String || bIsIndex && bIndex.Key.Type() != cty.String {
+		if !aIsIndex || !bIsIndex || !aIndex.Key.IsWhollyKnown() || !bIndex.Key.IsWhollyKnown() {
+			return false
+		}
+		return aIndex.Key.Equals(bIndex.Key).True()
+	}
+	name := stepName(a)
+	return name != "" && name == stepName(b)
+}
+
+func stepName(step hcl.Traverser) string {
+	switch s := step.(type) {
+	case hcl.TraverseRoot:
+		return s.Name
+	case hcl.TraverseAttr:
+		return s.Name
+	case hcl.TraverseIndex:
+		if s.Key.Type() == cty.String {
+			return s.Key.AsString()
+		}
+	}
+	return ""
+}
+
+func traversalsString(traversals []hcl.Traversal) string {
+	var names []string
+	for _, traversal := range traversals {
+		var sb strings.Builder
+		for i, step := range traversal {
+			if index, ok := step.(hcl.TraverseIndex); ok && index.Key.Type() == cty.Number && index.Key.IsKnown() {
+				sb.WriteString(fmt.Sprintf("[%s]", index.Key.AsBigFloat().Text('f', -1)))
+				continue
+			}
+			if i > 0 {
+				sb.WriteString(".")
+			}
+			sb.WriteString(stepName(step))
+		}
+		names = append(names, fmt.Sprintf("`%s`", sb.String()))
+	}
+	return strings.Join(names, " or ")
+}
+
+// checkInheritedTags checks whether the tags of a resource carry at least the tags of the `azurerm_resource_group`
+// referenced by its `resource_group_name` with the same values
+func (r *AzurermResourceTagRule) checkInheritedTags(runner tflint.Runner, azBlock *hclsyntax.Block, tags *hclsyntax.Attribute, resourceGroups map[string]*hclsyntax.Block) error {
+	rgName, ok := referencedResourceGroup(azBlock)
+	if !ok {
+		return nil
+	}
+	rg, ok := resourceGroups[rgName]
+	if !ok {
+		return nil
+	}
+	rgTags, ok := rg.Body.Attributes["tags"]
+	if !ok {
+		return nil
+	}
+	rgAddress := fmt.Sprintf("azurerm_resource_group.%s", rgName)
+	for _, traversal := range tags.Expr.Variables() {
+		if referencesAttribute(traversal, "azurerm_resource_group", rgName, "tags") {
+			return nil
+		}
+	}
+	wanted, ok := tagValues(runner, rgTags.Expr)
+	if !ok {
+		return nil
+	}
+	got, ok := tagValues(runner, tags.Expr)
+	if !ok {
+		return nil
+	}
+	address := fmt.Sprintf("%s.%s", azBlock.Labels[0], azBlock.Labels[1])
+	var missing []string
+	for _, key := range sortedKeys(wanted) {
+		value, ok := got[key]
+		if !ok {
+			missing = append(missing, fmt.Sprintf("`%s`", key))
+			continue
+		}
+		// the tags whose values are unknown, e.g. `owner = var.owner`, are only checked by key
+		if value == "" || wanted[key] == "" || value == wanted[key] {
+			continue
+		}
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("`tags` argument of resource `%s` overrides `%s` inherited from `%s`, got `%s` instead of `%s`",
+				address, key, rgAddress, value, wanted[key]),
+			tags.SrcRange,
+		); err != nil {
+			return err
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return runner.EmitIssue(
+		r,
+		fmt.Sprintf("`tags` argument of resource `%s` is missing %s inherited from `%s`", address, strings.Join(missing, ", "), rgAddress),
+		tags.SrcRange,
+	)
+}
+
+// resourceGroupBlocks collects the `azurerm_resource_group` blocks of the module, keyed by name
+func resourceGroupBlocks(runner tflint.Runner) (map[string]*hclsyntax.Block, error) {
+	files, err := runner.GetFiles()
+	if err != nil {
+		return nil, err
+	}
+	groups := make(map[string]*hclsyntax.Block)
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type == "resource" && len(block.Labels) == 2 && block.Labels[0] == "azurerm_resource_group" {
+				groups[block.Labels[1]] = block
+			}
+		}
+	}
+	return groups, nil
+}
+
+// referencedResourceGroup returns the name of the `azurerm_resource_group` resource
+// referenced by the `resource_group_name` argument of the block
+func referencedResourceGroup(block *hclsyntax.Block) (string, bool) {
+	attr, ok := block.Body.Attributes["resource_group_name"]
+	if !ok {
+		return "", false
+	}
+	for _, traversal := range attr.Expr.Variables() {
+		if len(traversal) < 2 || traversal.RootName() != "azurerm_resource_group" {
+			continue
+		}
+		name, ok := traversal[1].(hcl.TraverseAttr)
+		if !ok {
+			continue
+		}
+		if referencesAttribute(traversal, "azurerm_resource_group", name.Name, "name") {
+			return name.Name, true
+		}
+	}
+	return "", false
+}
+
+// referencesAttribute checks whether the traversal refers to the given attribute of a resource,
+// e.g. `azurerm_resource_group.example.name` or `azurerm_resource_group.example[0].name`
+func referencesAttribute(traversal hcl.Traversal, resourceType, resourceName, attrName string) bool {
+	if len(traversal) < 3 || traversal.RootName() != resourceType {
+		return false
+	}
+	name, ok := traversal[1].(hcl.TraverseAttr)
+	if !ok || name.Name != resourceName {
+		return false
+	}
+	for _, step := range traversal[2:] {
+		if attr, ok := step.(hcl.TraverseAttr); ok {
+			return attr.Name == attrName
+		}
+	}
+	return false
+}
+
+// tagValues evaluates a tags expression and returns its tags, the values which are not known strings are empty.
+// The second return value is false if the keys cannot be determined statically, including the sensitive and
+// ephemeral tags, which cannot be inspected
+func tagValues(runner tflint.Runner, expr hcl.Expression) (map[string]string, bool) {
+	var val cty.Value
+	if err := runner.EvaluateExpr(expr, &val, nil); err != nil {
+		return nil, false
+	}
+	if val.IsNull() || !val.IsKnown() || val.ContainsMarked() {
+		return nil, false
+	}
+	ty := val.Type()
+	if !ty.IsObjectType() && !ty.IsMapType() {
+		return nil, false
+	}
+	tags := make(map[string]string)
+	for it := val.ElementIterator(); it.Next(); {
+		key, value := it.Element()
+		tags[key.AsString()] = ""
+		if value, err := convert.Convert(value, cty.String); err == nil && value.IsKnown() && !value.IsNull() {
+			tags[key.AsString()] = value.AsString()
+		}
+	}
+	return tags, true
 }
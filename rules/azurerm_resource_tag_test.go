@@ -10,6 +10,7 @@ func Test_AzurermResourceTagRule(t *testing.T) {
 	cases := []struct {
 		Name     string
 		Content  string
+		Files    map[string]string
 		Expected helper.Issues
 	}{
 		{
@@ -76,12 +77,257 @@ resource "azurerm_container_registry" "acr" {
 }`,
 			Expected: helper.Issues{},
 		},
+		{
+			Name: "3. tags missing keys of the resource group",
+			Content: `
+resource "azurerm_resource_group" "rg" {
+  name     = "myTFResourceGroup"
+  location = "westus2"
+  tags = {
+    team        = "DevOps"
+    environment = "testing"
+  }
+}
+
+resource "azurerm_container_registry" "acr" {
+  name                = "containerRegistry1"
+  resource_group_name = azurerm_resource_group.rg.name
+  location            = azurerm_resource_group.rg.location
+  sku                 = "Premium"
+  tags = {
+    environment = "testing"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceTagRule(),
+					Message: "`tags` argument of resource `azurerm_container_registry.acr` is missing `team` inherited from `azurerm_resource_group.rg`",
+				},
+			},
+		},
+		{
+			Name: "4. tags derived from the resource group",
+			Content: `
+variable "tags" {
+  type    = map(string)
+  default = {
+    team = "DevOps"
+  }
+}
+
+resource "azurerm_resource_group" "rg" {
+  name     = "myTFResourceGroup"
+  location = "westus2"
+  tags     = var.tags
+}
+
+resource "azurerm_container_registry" "acr" {
+  name                = "containerRegistry1"
+  resource_group_name = azurerm_resource_group.rg.name
+  location            = azurerm_resource_group.rg.location
+  sku                 = "Premium"
+  tags                = azurerm_resource_group.rg.tags
+}
+
+resource "azurerm_container_registry" "acr2" {
+  name                = "containerRegistry2"
+  resource_group_name = azurerm_resource_group.rg.name
+  location            = azurerm_resource_group.rg.location
+  sku                 = "Premium"
+  tags = {
+    team  = "DevOps"
+    owner = "me"
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "5. resource group in another file",
+			Content: `
+resource "azurerm_container_registry" "acr" {
+  name                = "containerRegistry1"
+  resource_group_name = azurerm_resource_group.rg.name
+  location            = azurerm_resource_group.rg.location
+  sku                 = "Premium"
+  tags                = {}
+}`,
+			Files: map[string]string{
+				"rg.tf": `
+resource "azurerm_resource_group" "rg" {
+  name     = "myTFResourceGroup"
+  location = "westus2"
+  tags = {
+    team = "DevOps"
+  }
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceTagRule(),
+					Message: "`tags` argument of resource `azurerm_container_registry.acr` is missing `team` inherited from `azurerm_resource_group.rg`",
+				},
+			},
+		},
+		{
+			Name: "6. tags derive from configured sources",
+			Content: `
+locals {
+  tags = {
+    team = "DevOps"
+  }
+}
+
+resource "azurerm_container_registry" "acr" {
+  name                = "containerRegistry1"
+  resource_group_name = "rg"
+  location            = "eastus"
+  sku                 = "Premium"
+  tags                = merge(local.tags, { environment = "testing" })
+}
+
+resource "azurerm_container_registry" "acr2" {
+  name                = "containerRegistry2"
+  resource_group_name = "rg"
+  location            = "eastus"
+  sku                 = "Premium"
+  tags                = var.common_tags["default"]
+}
+
+resource "azurerm_container_registry" "acr3" {
+  name                = "containerRegistry3"
+  resource_group_name = "rg"
+  location            = "eastus"
+  sku                 = "Premium"
+  tags = {
+    team = "DevOps"
+  }
+}`,
+			Files: map[string]string{
+				".tflint.hcl": `
+rule "azurerm_resource_tag" {
+  enabled     = true
+  tag_sources = ["local.tags", "var.common_tags"]
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceTagRule(),
+					Message: "`tags` argument of resource `azurerm_container_registry.acr3` should derive from `local.tags` or `var.common_tags`",
+				},
+			},
+		},
+		{
+			Name: "7. tags derive from an element of a configured source",
+			Content: `
+locals {
+  tags = [
+    { team = "DevOps" },
+    { team = "Platform" },
+  ]
+}
+
+resource "azurerm_container_registry" "acr" {
+  name                = "containerRegistry1"
+  resource_group_name = "rg"
+  location            = "eastus"
+  sku                 = "Premium"
+  tags                = local.tags[0]
+}
+
+resource "azurerm_container_registry" "acr2" {
+  name                = "containerRegistry2"
+  resource_group_name = "rg"
+  location            = "eastus"
+  sku                 = "Premium"
+  tags                = local.tags[1]
+}`,
+			Files: map[string]string{
+				".tflint.hcl": `
+rule "azurerm_resource_tag" {
+  enabled     = true
+  tag_sources = ["local.tags[0]"]
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceTagRule(),
+					Message: "`tags` argument of resource `azurerm_container_registry.acr2` should derive from `local.tags[0]`",
+				},
+			},
+		},
+		{
+			Name: "8. sensitive tags of the resource group",
+			Content: `
+variable "tags" {
+  type      = map(string)
+  sensitive = true
+  default = {
+    team = "DevOps"
+  }
+}
+
+resource "azurerm_resource_group" "rg" {
+  name     = "myTFResourceGroup"
+  location = "westus2"
+  tags     = var.tags
+}
+
+resource "azurerm_container_registry" "acr" {
+  name                = "containerRegistry1"
+  resource_group_name = azurerm_resource_group.rg.name
+  location            = azurerm_resource_group.rg.location
+  sku                 = "Premium"
+  tags = {
+    environment = "testing"
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "9. tags overriding values of the resource group",
+			Content: `
+variable "owner" {
+  type = string
+}
+
+resource "azurerm_resource_group" "rg" {
+  name     = "myTFResourceGroup"
+  location = "westus2"
+  tags = {
+    team        = "DevOps"
+    environment = "prod"
+    owner       = var.owner
+  }
+}
+
+resource "azurerm_container_registry" "acr" {
+  name                = "containerRegistry1"
+  resource_group_name = azurerm_resource_group.rg.name
+  location            = azurerm_resource_group.rg.location
+  sku                 = "Premium"
+  tags = {
+    team        = "DevOps"
+    environment = "dev"
+    owner       = "me"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceTagRule(),
+					Message: "`tags` argument of resource `azurerm_container_registry.acr` overrides `environment` inherited from `azurerm_resource_group.rg`, got `dev` instead of `prod`",
+				},
+			},
+		},
 	}
 
 	rule := NewAzurermResourceTagRule()
 
 	for _, tc := range cases {
-		runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+		files := map[string]string{"config.tf": tc.Content}
+		for name, content := range tc.Files {
+			files[name] = content
+		}
+		runner := helper.TestRunner(t, files)
 		t.Run(tc.Name, func(t *testing.T) {
 			if err := rule.Check(runner); err != nil {
 				t.Fatalf("Unexpected error occurred: %s", err)
@@ -90,3 +336,17 @@ resource "azurerm_container_registry" "acr" {
 		})
 	}
 }
+
+func Test_AzurermResourceTagRule_InvalidTagSource(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{
+		"config.tf": `resource "azurerm_resource_group" "rg" {}`,
+		".tflint.hcl": `
+rule "azurerm_resource_tag" {
+  enabled     = true
+  tag_sources = ["azurerm_resource_group.rg.tags"]
+}`,
+	})
+	if err := NewAzurermResourceTagRule().Check(runner); err == nil {
+		t.Fatal("Expected error for tag source which is not a local or variable")
+	}
+}
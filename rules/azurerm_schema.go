@@ -1,35 +1,166 @@
 package rules
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
+	"github.com/hashicorp/go-version"
 	tfjson "github.com/hashicorp/terraform-json"
-	"github.com/lonegunmanb/terraform-azurerm-schema/v4/generated"
 )
 
+// azurermSchema is a version of the azurerm provider schema which the rules are checked against
+type azurermSchema struct {
+	// Version is the azurerm version of the schema, nil if the schema is loaded from a schema file
+	Version *version.Version
+	// Source describes where the schema comes from
+	Source    string
+	index     *schemaIndex
+	functions functionsFunc
+}
+
+func newAzurermSchema(v *version.Version, source string, roots rootSchemaFunc, functions functionsFunc) *azurermSchema {
+	return &azurermSchema{
+		Version:   v,
+		Source:    source,
+		index:     newSchemaIndex(roots),
+		functions: functions,
+	}
+}
+
+// bundledSchemas are the azurerm provider schemas compiled into the plugin, the latest comes first
+var bundledSchemas = loadBundledSchemas()
+
+// activeSchema is the schema the rules are checked against, nil if no bundled schema is close to the azurerm version
+// used by the module, in which case the checks based on the schema are skipped
+var activeSchema = bundledSchemas[0]
+
+// customSchema is the schema loaded from the `schema_file` plugin config, which replaces the bundled schemas
+var customSchema *azurermSchema
+
+// useAzurermSchema sets the azurerm provider schema used by the rules, nil to skip the checks based on the schema
+func useAzurermSchema(s *azurermSchema) {
+	activeSchema = s
+}
+
+// queryBlockSchema returns the schema of a (nested) block of resource, data source, ephemeral resource or
+// the azurerm provider configuration, e.g. `[]string{"ephemeral", "azurerm_key_vault_secret"}` or
+// `[]string{"provider", "azurerm", "features"}`, nil if not found
 func queryBlockSchema(path []string) *tfjson.SchemaBlock {
-	if path[0] != "resource" && path[0] != "data" {
+	if path[0] != "resource" && path[0] != "data" && path[0] != "ephemeral" && path[0] != "provider" {
 		return nil
 	}
 	if len(path) < 2 {
 		panic(fmt.Sprintf("invalid path:%v", path))
 	}
-	root := generated.Resources
-	if path[0] == "data" {
-		root = generated.DataSources
+	if activeSchema == nil {
+		return nil
 	}
+	return activeSchema.index.block(path)
+}
 
-	b, ok := root[path[1]]
-	if !ok {
+// queryFunctionSignature returns the signature of an azurerm provider-defined function, e.g. `parse_resource_id`,
+// nil if not found
+func queryFunctionSignature(name string) *tfjson.FunctionSignature {
+	if activeSchema == nil {
 		return nil
 	}
-	r := b.Block
-	for i := 2; i < len(path); i++ {
-		nb, ok := r.NestedBlocks[path[i]]
-		if !ok {
-			return nil
+	return activeSchema.functions()[name]
+}
+
+// BlockSchemaLookup returns the schema of a (nested) block by its path, e.g. `[]string{"resource", "azurerm_subnet"}`,
+// nil if not found
+type BlockSchemaLookup func(path []string) *tfjson.SchemaBlock
+
+// LoadSchema loads the bundled schema of an azurerm version, e.g. `4.31.0`,
+// or the `terraform providers schema -json` output at the path if it's not a bundled version
+func LoadSchema(versionOrPath string) (BlockSchemaLookup, error) {
+	if v, err := version.NewVersion(versionOrPath); err == nil {
+		var available []string
+		for _, s := range bundledSchemas {
+			if s.Version.Equal(v) {
+				return s.index.block, nil
+			}
+			available = append(available, s.Version.String())
+		}
+		if _, err = os.Stat(versionOrPath); os.IsNotExist(err) {
+			return nil, fmt.Errorf("no bundled azurerm schema of version %s, available versions: %s, pass the output of `terraform providers schema -json` with azurerm %s installed as a schema file instead", versionOrPath, strings.Join(available, ", "), versionOrPath)
+		}
+	}
+	s, err := loadSchemaFile(versionOrPath)
+	if err != nil {
+		return nil, err
+	}
+	return s.index.block, nil
+}
+
+// loadSchemaFile loads the azurerm schema from the output of `terraform providers schema -json`
+func loadSchemaFile(path string) (*azurermSchema, error) {
+	s, err := ReadSchemaFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return newAzurermSchema(nil, fmt.Sprintf("azurerm schema file %s", path), providerSchemaRoots(s), providerSchemaFunctions(s)), nil
+}
+
+// ReadSchemaFile reads and validates the azurerm provider schema in the output of `terraform providers schema -json`
+func ReadSchemaFile(path string) (*tfjson.ProviderSchema, error) {
+	src, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read azurerm schema file: %+v", err)
+	}
+	schemas := &tfjson.ProviderSchemas{}
+	if err = json.Unmarshal(src, schemas); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return nil, fmt.Errorf("malformed azurerm schema file %s at offset %d: %+v", path, syntaxErr.Offset, err)
+		}
+		return nil, fmt.Errorf("malformed azurerm schema file %s, `terraform providers schema -json` output is expected: %+v", path, err)
+	}
+	var names []string
+	for name := range schemas.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !isAzurermSource(name) && !strings.HasSuffix(name, "/azurerm") {
+			continue
+		}
+		s := schemas.Schemas[name]
+		if s == nil || len(s.ResourceSchemas) == 0 {
+			return nil, fmt.Errorf("azurerm schema file %s has no resource schemas for provider `%s`", path, name)
+		}
+		if err = validateProviderSchema(s); err != nil {
+			return nil, fmt.Errorf("invalid azurerm schema file %s: %+v", path, err)
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("azurerm schema file %s has no azurerm provider schema, found: %s", path, strings.Join(names, ", "))
+}
+
+func validateProviderSchema(s *tfjson.ProviderSchema) error {
+	for kind, schemas := range map[string]map[string]*tfjson.Schema{
+		"resource":           s.ResourceSchemas,
+		"data source":        s.DataSourceSchemas,
+		"ephemeral resource": s.EphemeralResourceSchemas,
+	} {
+		for name, schema := range schemas {
+			if schema == nil || schema.Block == nil {
+				return fmt.Errorf("%s `%s` has no block schema", kind, name)
+			}
+		}
+	}
+	if s.ConfigSchema != nil && s.ConfigSchema.Block == nil {
+		return fmt.Errorf("provider configuration has no block schema")
+	}
+	for name, f := range s.Functions {
+		if f == nil {
+			return fmt.Errorf("function `%s` has no signature", name)
 		}
-		r = nb.Block
 	}
-	return r
+	return nil
 }
@@ -0,0 +1,61 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.Rule = new(AzurermSchemaVersionRule)
+
+// AzurermSchemaVersionRule reports when no bundled azurerm schema is close to the azurerm version used by the module,
+// or the declared azurerm version is invalid
+type AzurermSchemaVersionRule struct {
+	tflint.DefaultRule
+}
+
+// NewAzurermSchemaVersionRule returns a new rule
+func NewAzurermSchemaVersionRule() *AzurermSchemaVersionRule {
+	return &AzurermSchemaVersionRule{}
+}
+
+func (r *AzurermSchemaVersionRule) Name() string {
+	return "azurerm_schema_version"
+}
+
+func (r *AzurermSchemaVersionRule) Enabled() bool {
+	return true
+}
+
+func (r *AzurermSchemaVersionRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+func (r *AzurermSchemaVersionRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+func (r *AzurermSchemaVersionRule) Check(runner tflint.Runner) error {
+	resolution, err := resolveAzurermSchema(runner)
+	if err != nil {
+		return err
+	}
+	if resolution.Ignored != "" {
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s, the azurerm version declared there is ignored", resolution.Ignored),
+			resolution.IgnoredRange,
+		); err != nil {
+			return err
+		}
+	}
+	if resolution.Close {
+		return nil
+	}
+	return runner.EmitIssue(
+		r,
+		fmt.Sprintf("no bundled azurerm schema is close to `%s`, the checks based on the azurerm schema are skipped", resolution.Requested),
+		resolution.Range,
+	)
+}
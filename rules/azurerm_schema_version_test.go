@@ -0,0 +1,237 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermSchemaVersionRule(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Content  string
+		LockFile string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. no version declared",
+			Content: `
+resource "azurerm_resource_group" "rg" {
+  location = "eastus"
+  name     = "rg"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. close constraint",
+			Content: `
+terraform {
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 4.0"
+    }
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "3. no close schema for constraint",
+			Content: `
+terraform {
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 3.0"
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermSchemaVersionRule(),
+					Message: "no bundled azurerm schema is close to `~> 3.0`, the checks based on the azurerm schema are skipped",
+				},
+			},
+		},
+		{
+			Name: "4. lock file takes precedence over constraint",
+			Content: `
+terraform {
+  required_providers {
+    arm = {
+      source  = "hashicorp/azurerm"
+      version = ">= 3.0"
+    }
+  }
+}`,
+			LockFile: `
+provider "registry.terraform.io/hashicorp/azurerm" {
+  version     = "3.116.0"
+  constraints = ">= 3.0.0"
+  hashes = [
+    "h1:BCR3NIorFSvGG3v/+JOiiw3VM4PkChLO4m84szTFYY=",
+  ]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermSchemaVersionRule(),
+					Message: "no bundled azurerm schema is close to `3.116.0`, the checks based on the azurerm schema are skipped",
+				},
+			},
+		},
+		{
+			Name:    "5. close locked version",
+			Content: `resource "azurerm_resource_group" "rg" {}`,
+			LockFile: `
+provider "registry.terraform.io/hashicorp/azurerm" {
+  version = "4.35.0"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name:    "6. locked version too far from the bundled schemas",
+			Content: `resource "azurerm_resource_group" "rg" {}`,
+			LockFile: `
+provider "registry.terraform.io/hashicorp/azurerm" {
+  version = "4.5.0"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermSchemaVersionRule(),
+					Message: "no bundled azurerm schema is close to `4.5.0`, the checks based on the azurerm schema are skipped",
+				},
+			},
+		},
+		{
+			Name: "7. malformed constraint",
+			Content: `
+terraform {
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> four"
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermSchemaVersionRule(),
+					Message: "invalid azurerm version constraint `~> four`: Malformed constraint: ~> four, the azurerm version declared there is ignored",
+				},
+			},
+		},
+		{
+			Name: "8. malformed lock file falls back to constraint",
+			Content: `
+terraform {
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 3.0"
+    }
+  }
+}`,
+			LockFile: `provider "registry.terraform.io/hashicorp/azurerm" {`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermSchemaVersionRule(),
+					Message: "malformed .terraform.lock.hcl: Unclosed configuration block, the azurerm version declared there is ignored",
+				},
+				{
+					Rule:    NewAzurermSchemaVersionRule(),
+					Message: "no bundled azurerm schema is close to `~> 3.0`, the checks based on the azurerm schema are skipped",
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermSchemaVersionRule()
+
+	for _, tc := range cases {
+		dir := t.TempDir()
+		if tc.LockFile != "" {
+			if err := os.WriteFile(filepath.Join(dir, lockFileName), []byte(tc.LockFile), 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+		runner := helper.TestRunner(t, map[string]string{filepath.Join(dir, "config.tf"): tc.Content})
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_SelectSchema(t *testing.T) {
+	schemas := []*azurermSchema{
+		{Version: version.Must(version.NewVersion("4.31.0"))},
+		{Version: version.Must(version.NewVersion("4.10.0"))},
+		{Version: version.Must(version.NewVersion("3.116.0"))},
+	}
+	cases := []struct {
+		Requested    string
+		IsConstraint bool
+		Expected     string
+		Close        bool
+	}{
+		{Requested: "4.31.0", Expected: "4.31.0", Close: true},
+		{Requested: "4.14.2", Expected: "4.10.0", Close: true},
+		{Requested: "4.20.0", Expected: "4.10.0", Close: false},
+		{Requested: "4.5.0", Expected: "4.10.0", Close: true},
+		{Requested: "4.1.0", Expected: "4.10.0", Close: false},
+		{Requested: "4.36.0", Expected: "4.31.0", Close: true},
+		{Requested: "3.117.1", Expected: "3.116.0", Close: true},
+		{Requested: "2.99.0", Expected: "4.31.0", Close: false},
+		{Requested: "~> 3.0", IsConstraint: true, Expected: "3.116.0", Close: true},
+		{Requested: ">= 4.0, < 4.20", IsConstraint: true, Expected: "4.10.0", Close: true},
+		{Requested: "~> 5.0", IsConstraint: true, Expected: "4.31.0", Close: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Requested, func(t *testing.T) {
+			resolution := selectSchemaByVersion(schemas, tc.Requested)
+			if tc.IsConstraint {
+				var err error
+				if resolution, err = selectSchemaByConstraint(schemas, tc.Requested); err != nil {
+					t.Fatalf("Unexpected error occurred: %s", err)
+				}
+			}
+			if actual := resolution.Schema.Version.String(); actual != tc.Expected || resolution.Close != tc.Close {
+				t.Fatalf("Expected schema %s (close: %t), got %s (close: %t)", tc.Expected, tc.Close, actual, resolution.Close)
+			}
+		})
+	}
+}
+
+func Test_SinceMajorVersion(t *testing.T) {
+	cases := []struct {
+		Requested string
+		Locked    bool
+		Close     bool
+		Expected  bool
+	}{
+		{Requested: "4.31.0", Locked: true, Close: true, Expected: true},
+		{Requested: "3.117.1", Locked: true, Close: true, Expected: false},
+		{Requested: ">= 4.0", Close: true, Expected: true},
+		{Requested: "~> 4.1, != 4.2.0", Close: true, Expected: true},
+		{Requested: "4.10.0", Close: true, Expected: true},
+		{Requested: ">= 3.0", Close: true, Expected: false},
+		{Requested: ">= 3.0, < 5.0", Close: true, Expected: false},
+		{Requested: "!= 3.0.0", Close: true, Expected: false},
+		{Requested: "~> 5.0", Close: false, Expected: false},
+		{Requested: "", Close: true, Expected: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Requested, func(t *testing.T) {
+			resolution := &schemaResolution{Schema: bundledSchemas[0], Requested: tc.Requested, Locked: tc.Locked, Close: tc.Close}
+			if actual := resolution.sinceMajorVersion(4); actual != tc.Expected {
+				t.Fatalf("Expected %t, got %t", tc.Expected, actual)
+			}
+		})
+	}
+}
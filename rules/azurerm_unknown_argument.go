@@ -0,0 +1,219 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/project"
+	"github.com/agext/levenshtein"
+	"github.com/ahmetb/go-linq/v3"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// maxSuggestionDistance is the max levenshtein distance between an unknown name and a suggested one
+const maxSuggestionDistance = 2
+
+// topLevelMetaArgs are the meta args of the top level blocks, which are not in the azurerm schema
+var topLevelMetaArgs = map[string][]string{
+	"provider":  {"alias", "version"},
+	"resource":  {"count", "for_each", "provider", "depends_on"},
+	"data":      {"count", "for_each", "provider", "depends_on"},
+	"ephemeral": {"count", "for_each", "provider", "depends_on"},
+}
+
+// topLevelMetaBlocks are the meta nested blocks of the top level blocks, which are not in the azurerm schema
+var topLevelMetaBlocks = map[string][]string{
+	"resource":  {"lifecycle", "connection", "provisioner"},
+	"data":      {"lifecycle"},
+	"ephemeral": {"lifecycle"},
+}
+
+var _ tflint.Rule = new(AzurermUnknownArgumentRule)
+
+// AzurermUnknownArgumentRule checks whether the arguments and nested blocks are defined in the azurerm schema
+type AzurermUnknownArgumentRule struct {
+	tflint.DefaultRule
+}
+
+// NewAzurermUnknownArgumentRule returns a new rule
+func NewAzurermUnknownArgumentRule() *AzurermUnknownArgumentRule {
+	return &AzurermUnknownArgumentRule{}
+}
+
+func (r *AzurermUnknownArgumentRule) Name() string {
+	return "azurerm_unknown_argument"
+}
+
+func (r *AzurermUnknownArgumentRule) Enabled() bool {
+	return true
+}
+
+func (r *AzurermUnknownArgumentRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *AzurermUnknownArgumentRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+func (r *AzurermUnknownArgumentRule) Check(runner tflint.Runner) error {
+	return Check(runner, r.CheckFile)
+}
+
+// CheckFile checks the provider, resource and data source blocks in the file for arguments and nested blocks which
+// are not in the azurerm schema
+func (r *AzurermUnknownArgumentRule) CheckFile(runner tflint.Runner, file *hcl.File) error {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		logger.Debug("skip azurerm_unknown_argument since it's not hcl file")
+		return nil
+	}
+	var issues []pendingIssue
+	var err error
+	for _, block := range azurermBlocks(body) {
+		issues = append(issues, r.checkBody([]string{block.Type, block.Labels[0]}, block.Body)...)
+		subErr := BuildResourceBlock(block, file, nil).Walk(&BlockVisitor{
+			Block: func(path []string, nb *NestedBlock) error {
+				// the nested blocks of an unknown block are reported along with it
+				if queryBlockSchema(path) != nil {
+					issues = append(issues, r.checkBody(path, blockBody(nb.Block))...)
+				}
+				return nil
+			},
+		})
+		if subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return emitIssues(runner, r, issues)
+}
+
+func (r *AzurermUnknownArgumentRule) checkBody(path []string, body *hclsyntax.Body) []pendingIssue {
+	schema := queryBlockSchema(path)
+	if schema == nil || body == nil {
+		return nil
+	}
+	topLevel := len(path) == 2
+	var issues []pendingIssue
+	for name, attr := range body.Attributes {
+		if topLevel && linq.From(topLevelMetaArgs[path[0]]).Contains(name) {
+			continue
+		}
+		if _, ok := schema.Attributes[name]; ok {
+			continue
+		}
+		subject := fmt.Sprintf("`%s` of %s `%s`", strings.Join(argPath(path[2:], name), "."), blockKind(path[0]), path[1])
+		if _, ok := schema.NestedBlocks[name]; ok {
+			issues = append(issues, pendingIssue{Message: subject + " must be defined as a block", Range: attr.SrcRange})
+			continue
+		}
+		var candidates []string
+		for candidate, attrSchema := range schema.Attributes {
+			if _, set := body.Attributes[candidate]; !set && (attrSchema.Required || attrSchema.Optional) {
+				candidates = append(candidates, candidate)
+			}
+		}
+		suggestions := suggestNames(name, candidates)
+		issue := pendingIssue{Message: unknownMessage(subject, "argument", suggestions), Range: attr.SrcRange}
+		if len(suggestions) == 1 {
+			nameRange, suggestion := attr.NameRange, suggestions[0]
+			issue.Fix = func(f tflint.Fixer) error {
+				return f.ReplaceText(nameRange, suggestion)
+			}
+		}
+		issues = append(issues, issue)
+	}
+	for _, nb := range body.Blocks {
+		if topLevel && linq.From(topLevelMetaBlocks[path[0]]).Contains(nb.Type) {
+			continue
+		}
+		name, nameRange := nb.Type, nb.TypeRange
+		if nb.Type == "dynamic" {
+			if len(nb.Labels) == 0 {
+				continue
+			}
+			name, nameRange = nb.Labels[0], nb.LabelRanges[0]
+		}
+		if _, ok := schema.NestedBlocks[name]; ok {
+			continue
+		}
+		if attr, ok := schema.Attributes[name]; ok && attributeAsBlock(attr) {
+			continue
+		}
+		subject := fmt.Sprintf("`%s` of %s `%s`", strings.Join(argPath(path[2:], name), "."), blockKind(path[0]), path[1])
+		if _, ok := schema.Attributes[name]; ok {
+			issues = append(issues, pendingIssue{Message: subject + " must be defined as an argument", Range: nb.DefRange()})
+			continue
+		}
+		var candidates []string
+		for candidate := range schema.NestedBlocks {
+			candidates = append(candidates, candidate)
+		}
+		for candidate, attr := range schema.Attributes {
+			if attributeAsBlock(attr) {
+				candidates = append(candidates, candidate)
+			}
+		}
+		suggestions := suggestNames(name, candidates)
+		issue := pendingIssue{Message: unknownMessage(subject, "block", suggestions), Range: nb.DefRange()}
+		// renaming a dynamic block without `iterator` would break the references to its iterator in the content
+		_, hasIterator := nb.Body.Attributes["iterator"]
+		if len(suggestions) == 1 && (nb.Type != "dynamic" || hasIterator) {
+			replacement := suggestions[0]
+			if nb.Type == "dynamic" {
+				replacement = fmt.Sprintf("%q", replacement)
+			}
+			issue.Fix = func(f tflint.Fixer) error {
+				return f.ReplaceText(nameRange, replacement)
+			}
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+func unknownMessage(subject, kind string, suggestions []string) string {
+	msg := fmt.Sprintf("%s is not a valid %s", subject, kind)
+	if len(suggestions) == 0 {
+		return msg
+	}
+	return fmt.Sprintf("%s, did you mean `%s`?", msg, strings.Join(suggestions, "` or `"))
+}
+
+// suggestNames returns the candidates close to the name, or extending it by whole words, e.g. `resource_group_name`
+// for `resource_group`, the closest comes first
+func suggestNames(name string, candidates []string) []string {
+	distances := make(map[string]int)
+	var suggestions []string
+	for _, candidate := range candidates {
+		d := levenshtein.Distance(name, candidate, nil)
+		extends := strings.HasPrefix(candidate, name+"_") || strings.HasSuffix(candidate, "_"+name)
+		if d <= maxSuggestionDistance || extends {
+			distances[candidate] = d
+			suggestions = append(suggestions, candidate)
+		}
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if distances[suggestions[i]] != distances[suggestions[j]] {
+			return distances[suggestions[i]] < distances[suggestions[j]]
+		}
+		return suggestions[i] < suggestions[j]
+	})
+	return suggestions
+}
+
+// attributeAsBlock checks whether the attribute is a list or set of objects, which can also be defined as blocks,
+// e.g. `security_rule` of `azurerm_network_security_group`
+func attributeAsBlock(attr *tfjson.SchemaAttribute) bool {
+	t := attr.AttributeType
+	return (t.IsListType() || t.IsSetType()) && t.ElementType().IsObjectType()
+}
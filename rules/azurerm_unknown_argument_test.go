@@ -0,0 +1,385 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermUnknownArgumentRule(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+		Fixed    string
+	}{
+		{
+			Name: "1. known arguments and meta args",
+			Content: `
+resource "azurerm_resource_group" "example" {
+  count    = 2
+  provider = azurerm.secondary
+  name     = "example-${count.index}"
+  location = "westeurope"
+
+  lifecycle {
+    ignore_changes = [tags]
+  }
+  depends_on = [azurerm_resource_group.other]
+}
+
+provider "azurerm" {
+  alias           = "secondary"
+  subscription_id = var.subscription_id
+
+  features {}
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. misspelled argument with a single suggestion",
+			Content: `
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  locaton  = "westeurope"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermUnknownArgumentRule(),
+					Message: "`locaton` of resource `azurerm_resource_group` is not a valid argument, did you mean `location`?",
+				},
+			},
+			Fixed: `
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "westeurope"
+}`,
+		},
+		{
+			Name: "3. unknown argument without suggestion",
+			Content: `
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "westeurope"
+  sku      = "Standard"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermUnknownArgumentRule(),
+					Message: "`sku` of resource `azurerm_resource_group` is not a valid argument",
+				},
+			},
+		},
+		{
+			Name: "4. suggestion already set",
+			Content: `
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  names    = "example"
+  location = "westeurope"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermUnknownArgumentRule(),
+					Message: "`names` of resource `azurerm_resource_group` is not a valid argument",
+				},
+			},
+		},
+		{
+			Name: "5. argument missing words of a known one",
+			Content: `
+resource "azurerm_storage_account" "example" {
+  name           = "example"
+  resource_group = azurerm_resource_group.example.name
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermUnknownArgumentRule(),
+					Message: "`resource_group` of resource `azurerm_storage_account` is not a valid argument, did you mean `resource_group_name`?",
+				},
+			},
+			Fixed: `
+resource "azurerm_storage_account" "example" {
+  name                = "example"
+  resource_group_name = azurerm_resource_group.example.name
+}`,
+		},
+		{
+			Name: "6. nested blocks",
+			Content: `
+resource "azurerm_kubernetes_cluster" "example" {
+  name = "example"
+
+  default_node_pool {
+    name    = "default"
+    vm_sze  = "Standard_D2_v2"
+
+    upgrade_setting {
+      max_surge = "10%"
+    }
+  }
+  identity = {
+    type = "SystemAssigned"
+  }
+  tags {
+    env = "dev"
+  }
+  unknown {
+    name = "example"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermUnknownArgumentRule(),
+					Message: "`default_node_pool.vm_sze` of resource `azurerm_kubernetes_cluster` is not a valid argument, did you mean `vm_size`?",
+				},
+				{
+					Rule:    NewAzurermUnknownArgumentRule(),
+					Message: "`default_node_pool.upgrade_setting` of resource `azurerm_kubernetes_cluster` is not a valid block, did you mean `upgrade_settings`?",
+				},
+				{
+					Rule:    NewAzurermUnknownArgumentRule(),
+					Message: "`identity` of resource `azurerm_kubernetes_cluster` must be defined as a block",
+				},
+				{
+					Rule:    NewAzurermUnknownArgumentRule(),
+					Message: "`tags` of resource `azurerm_kubernetes_cluster` must be defined as an argument",
+				},
+				{
+					Rule:    NewAzurermUnknownArgumentRule(),
+					Message: "`unknown` of resource `azurerm_kubernetes_cluster` is not a valid block",
+				},
+			},
+			Fixed: `
+resource "azurerm_kubernetes_cluster" "example" {
+  name = "example"
+
+  default_node_pool {
+    name    = "default"
+    vm_size = "Standard_D2_v2"
+
+    upgrade_settings {
+      max_surge = "10%"
+    }
+  }
+  identity = {
+    type = "SystemAssigned"
+  }
+  tags {
+    env = "dev"
+  }
+  unknown {
+    name = "example"
+  }
+}`,
+		},
+		{
+			Name: "7. dynamic blocks",
+			Content: `
+resource "azurerm_network_security_group" "example" {
+  name                = "example"
+  location            = "westeurope"
+  resource_group_name = "example"
+
+  security_rule {
+    name = "allow-https"
+  }
+  dynamic "security_rules" {
+    for_each = var.rules
+
+    content {
+      name = security_rules.value.name
+    }
+  }
+  dynamic "security_rul" {
+    for_each = var.rules
+    iterator = rule
+
+    content {
+      name    = rule.value.name
+      protocl = rule.value.protocol
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermUnknownArgumentRule(),
+					Message: "`security_rules` of resource `azurerm_network_security_group` is not a valid block, did you mean `security_rule`?",
+				},
+				{
+					Rule:    NewAzurermUnknownArgumentRule(),
+					Message: "`security_rul` of resource `azurerm_network_security_group` is not a valid block, did you mean `security_rule`?",
+				},
+			},
+			Fixed: `
+resource "azurerm_network_security_group" "example" {
+  name                = "example"
+  location            = "westeurope"
+  resource_group_name = "example"
+
+  security_rule {
+    name = "allow-https"
+  }
+  dynamic "security_rules" {
+    for_each = var.rules
+
+    content {
+      name = security_rules.value.name
+    }
+  }
+  dynamic "security_rule" {
+    for_each = var.rules
+    iterator = rule
+
+    content {
+      name    = rule.value.name
+      protocl = rule.value.protocol
+    }
+  }
+}`,
+		},
+		{
+			Name: "8. dynamic block suggestions",
+			Content: `
+resource "azurerm_kubernetes_cluster" "example" {
+  name = "example"
+
+  dynamic "default_node_poo" {
+    for_each = [1]
+
+    content {
+      name = "default"
+    }
+  }
+  dynamic "identty" {
+    for_each = [1]
+    iterator = id
+
+    content {
+      typ = "SystemAssigned"
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermUnknownArgumentRule(),
+					Message: "`default_node_poo` of resource `azurerm_kubernetes_cluster` is not a valid block, did you mean `default_node_pool`?",
+				},
+				{
+					Rule:    NewAzurermUnknownArgumentRule(),
+					Message: "`identty` of resource `azurerm_kubernetes_cluster` is not a valid block, did you mean `identity`?",
+				},
+			},
+			Fixed: `
+resource "azurerm_kubernetes_cluster" "example" {
+  name = "example"
+
+  dynamic "default_node_poo" {
+    for_each = [1]
+
+    content {
+      name = "default"
+    }
+  }
+  dynamic "identity" {
+    for_each = [1]
+    iterator = id
+
+    content {
+      typ = "SystemAssigned"
+    }
+  }
+}`,
+		},
+		{
+			Name: "9. dynamic block content",
+			Content: `
+resource "azurerm_kubernetes_cluster" "example" {
+  name = "example"
+
+  dynamic "identity" {
+    for_each = [1]
+
+    content {
+      typ = "SystemAssigned"
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermUnknownArgumentRule(),
+					Message: "`identity.typ` of resource `azurerm_kubernetes_cluster` is not a valid argument, did you mean `type`?",
+				},
+			},
+			Fixed: `
+resource "azurerm_kubernetes_cluster" "example" {
+  name = "example"
+
+  dynamic "identity" {
+    for_each = [1]
+
+    content {
+      type = "SystemAssigned"
+    }
+  }
+}`,
+		},
+		{
+			Name: "10. provider and data source",
+			Content: `
+provider "azurerm" {
+  subscription = var.subscription_id
+
+  features {
+    key_vaults {}
+  }
+}
+
+data "azurerm_resource_group" "example" {
+  nam = "example"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermUnknownArgumentRule(),
+					Message: "`subscription` of provider `azurerm` is not a valid argument, did you mean `subscription_id`?",
+				},
+				{
+					Rule:    NewAzurermUnknownArgumentRule(),
+					Message: "`features.key_vaults` of provider `azurerm` is not a valid block, did you mean `key_vault`?",
+				},
+				{
+					Rule:    NewAzurermUnknownArgumentRule(),
+					Message: "`nam` of data source `azurerm_resource_group` is not a valid argument, did you mean `name`?",
+				},
+			},
+			Fixed: `
+provider "azurerm" {
+  subscription_id = var.subscription_id
+
+  features {
+    key_vault {}
+  }
+}
+
+data "azurerm_resource_group" "example" {
+  name = "example"
+}`,
+		},
+	}
+
+	rule := NewAzurermUnknownArgumentRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+			if fixed := string(runner.Changes()["config.tf"]); tc.Fixed != "" && fixed != tc.Fixed {
+				t.Fatalf("Expected fixed content:\n%s\ngot:\n%s", tc.Fixed, fixed)
+			}
+		})
+	}
+}
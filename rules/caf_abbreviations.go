@@ -0,0 +1,68 @@
+package rules
+
+// cafAbbreviations are the abbreviations of the resource types recommended by the Cloud Adoption Framework, see
+// https://learn.microsoft.com/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations
+var cafAbbreviations = map[string]string{
+	"azurerm_api_management":                    "apim",
+	"azurerm_app_configuration":                 "appcs",
+	"azurerm_application_gateway":               "agw",
+	"azurerm_application_insights":              "appi",
+	"azurerm_automation_account":                "aa",
+	"azurerm_bastion_host":                      "bas",
+	"azurerm_cdn_frontdoor_profile":             "afd",
+	"azurerm_container_app":                     "ca",
+	"azurerm_container_app_environment":         "cae",
+	"azurerm_container_registry":                "cr",
+	"azurerm_cosmosdb_account":                  "cosmos",
+	"azurerm_data_factory":                      "adf",
+	"azurerm_databricks_workspace":              "dbw",
+	"azurerm_eventgrid_topic":                   "evgt",
+	"azurerm_eventhub":                          "evh",
+	"azurerm_eventhub_namespace":                "evhns",
+	"azurerm_firewall":                          "afw",
+	"azurerm_firewall_policy":                   "afwp",
+	"azurerm_key_vault":                         "kv",
+	"azurerm_kubernetes_cluster":                "aks",
+	"azurerm_linux_function_app":                "func",
+	"azurerm_linux_virtual_machine":             "vm",
+	"azurerm_linux_virtual_machine_scale_set":   "vmss",
+	"azurerm_linux_web_app":                     "app",
+	"azurerm_local_network_gateway":             "lgw",
+	"azurerm_log_analytics_workspace":           "log",
+	"azurerm_machine_learning_workspace":        "mlw",
+	"azurerm_managed_disk":                      "disk",
+	"azurerm_monitor_action_group":              "ag",
+	"azurerm_mssql_database":                    "sqldb",
+	"azurerm_mssql_server":                      "sql",
+	"azurerm_mysql_flexible_server":             "mysql",
+	"azurerm_nat_gateway":                       "ng",
+	"azurerm_network_interface":                 "nic",
+	"azurerm_network_security_group":            "nsg",
+	"azurerm_postgresql_flexible_server":        "psql",
+	"azurerm_private_endpoint":                  "pep",
+	"azurerm_public_ip":                         "pip",
+	"azurerm_recovery_services_vault":           "rsv",
+	"azurerm_redis_cache":                       "redis",
+	"azurerm_resource_group":                    "rg",
+	"azurerm_route_table":                       "rt",
+	"azurerm_search_service":                    "srch",
+	"azurerm_service_plan":                      "asp",
+	"azurerm_servicebus_namespace":              "sbns",
+	"azurerm_servicebus_queue":                  "sbq",
+	"azurerm_servicebus_topic":                  "sbt",
+	"azurerm_signalr_service":                   "sigr",
+	"azurerm_static_web_app":                    "stapp",
+	"azurerm_storage_account":                   "st",
+	"azurerm_subnet":                            "snet",
+	"azurerm_synapse_workspace":                 "synw",
+	"azurerm_user_assigned_identity":            "id",
+	"azurerm_virtual_hub":                       "vhub",
+	"azurerm_virtual_network":                   "vnet",
+	"azurerm_virtual_network_gateway":           "vgw",
+	"azurerm_virtual_wan":                       "vwan",
+	"azurerm_web_application_firewall_policy":   "waf",
+	"azurerm_windows_function_app":              "func",
+	"azurerm_windows_virtual_machine":           "vm",
+	"azurerm_windows_virtual_machine_scale_set": "vmss",
+	"azurerm_windows_web_app":                   "app",
+}
@@ -1,8 +1,11 @@
 package rules
 
 import (
+	"sort"
+
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
@@ -34,3 +37,102 @@ func Check(runner tflint.Runner, check func(tflint.Runner, *hcl.File) error) err
 	}
 	return err
 }
+
+// azurermBlocks returns the provider, resource, data source and ephemeral resource blocks in the body which have
+// an azurerm schema
+func azurermBlocks(body *hclsyntax.Body) []*hclsyntax.Block {
+	var blocks []*hclsyntax.Block
+	for _, block := range body.Blocks {
+		if len(block.Labels) == 0 {
+			continue
+		}
+		switch block.Type {
+		case "provider", "resource", "data", "ephemeral":
+		default:
+			continue
+		}
+		if queryBlockSchema([]string{block.Type, block.Labels[0]}) != nil {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// blockBody returns the body of the block, or the body of the `content` block of a dynamic block, nil if the dynamic
+// block has no content
+func blockBody(block *hclsyntax.Block) *hclsyntax.Body {
+	if block.Type != "dynamic" {
+		return block.Body
+	}
+	for _, nb := range block.Body.Blocks {
+		if nb.Type == "content" {
+			return nb.Body
+		}
+	}
+	return nil
+}
+
+// blockKind names the kind of a top level block in issue messages, e.g. `data source` for `data`
+func blockKind(blockType string) string {
+	switch blockType {
+	case "data":
+		return "data source"
+	case "ephemeral":
+		return "ephemeral resource"
+	}
+	return blockType
+}
+
+// pendingIssue is an issue collected while walking a block, with an optional autofix
+type pendingIssue struct {
+	Message string
+	Range   hcl.Range
+	Fix     func(f tflint.Fixer) error
+}
+
+// emitIssues emits the issues in source order, since args and nested blocks are walked section by section
+func emitIssues(runner tflint.Runner, rule tflint.Rule, issues []pendingIssue) error {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Range.Filename != issues[j].Range.Filename {
+			return issues[i].Range.Filename < issues[j].Range.Filename
+		}
+		return issues[i].Range.Start.Byte < issues[j].Range.Start.Byte
+	})
+	var err error
+	for _, issue := range issues {
+		var subErr error
+		if issue.Fix != nil {
+			subErr = runner.EmitIssueWithFix(rule, issue.Message, issue.Range, issue.Fix)
+		} else {
+			subErr = runner.EmitIssue(rule, issue.Message, issue.Range)
+		}
+		if subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	return err
+}
+
+// localAttributes returns the attributes of the `locals` blocks in the module keyed by name
+func localAttributes(runner tflint.Runner) (map[string]*hclsyntax.Attribute, error) {
+	files, err := runner.GetFiles()
+	if err != nil {
+		return nil, err
+	}
+	locals := make(map[string]*hclsyntax.Attribute)
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type != "locals" {
+				continue
+			}
+			for name, attr := range block.Body.Attributes {
+				locals[name] = attr
+			}
+		}
+	}
+	return locals, nil
+}
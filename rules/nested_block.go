@@ -136,6 +136,61 @@ func (b *NestedBlocks) add(arg *NestedBlock) {
 	}
 }
 
+// Walk visits the nestedBlock, its args and nested blocks recursively with their schema paths.
+// A dynamic block is visited as the block it generates, its own args are skipped and its content is visited as its body
+func (b *NestedBlock) Walk(visitor *BlockVisitor) error {
+	if b.Block.Type == "dynamic" {
+		var err error
+		if visitor.Block != nil {
+			err = visitor.Block(b.ParentBlockNames, b)
+		}
+		for _, nb := range b.nestedBlocks() {
+			if nb.Name != "content" {
+				continue
+			}
+			if subErr := nb.walkBody(visitor); subErr != nil {
+				err = multierror.Append(err, subErr)
+			}
+		}
+		return err
+	}
+	var err error
+	if visitor.Block != nil {
+		err = visitor.Block(b.ParentBlockNames, b)
+	}
+	if subErr := b.walkBody(visitor); subErr != nil {
+		err = multierror.Append(err, subErr)
+	}
+	return err
+}
+
+func (b *NestedBlock) walkBody(visitor *BlockVisitor) error {
+	var err error
+	// `count`, `for_each` and `provider` are not meta args in nested blocks except dynamic blocks, whose args are skipped
+	argSections := []*Args{b.RequiredArgs, b.OptionalArgs}
+	if b.HeadMetaArgs != nil {
+		argSections = append(argSections, &Args{Args: b.HeadMetaArgs.Args})
+	}
+	if visitor.Arg != nil {
+		for _, args := range argSections {
+			if args == nil {
+				continue
+			}
+			for _, arg := range args.Args {
+				if subErr := visitor.Arg(argPath(b.ParentBlockNames, arg.Name), arg); subErr != nil {
+					err = multierror.Append(err, subErr)
+				}
+			}
+		}
+	}
+	for _, nb := range b.nestedBlocks() {
+		if subErr := nb.Walk(visitor); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	return err
+}
+
 func (b *NestedBlock) nestedBlocks() []*NestedBlock {
 	var nbs []*NestedBlock
 	for _, subNbs := range []*NestedBlocks{b.RequiredNestedBlocks, b.OptionalNestedBlocks} {
@@ -189,7 +244,7 @@ func (b *NestedBlock) buildNestedBlock(nestedBlock *hclsyntax.Block) {
 	if nestedBlockName == "content" && b.Block.Type == "dynamic" {
 		parentBlockNames = b.ParentBlockNames
 	} else {
-		parentBlockNames = append(b.ParentBlockNames, nestedBlockName)
+		parentBlockNames = append(append([]string{}, b.ParentBlockNames...), nestedBlockName)
 	}
 	nb := &NestedBlock{
 		Name:             nestedBlockName,
@@ -24,6 +24,13 @@ type Block interface {
 	DefRange() hcl.Range
 }
 
+// BlockVisitor is invoked on the args and nested blocks of a block with their schema paths,
+// e.g. `[]string{"resource", "azurerm_kubernetes_cluster", "default_node_pool", "vm_size"}`
+type BlockVisitor struct {
+	Arg   func(path []string, arg *Arg) error
+	Block func(path []string, nb *NestedBlock) error
+}
+
 // ResourceBlock is the wrapper of a resource block
 type ResourceBlock struct {
 	File                 *hcl.File
@@ -106,6 +113,37 @@ func (b *ResourceBlock) ToString() string {
 	return string(hclwrite.Format([]byte(txt)))
 }
 
+// Walk visits the args and nested blocks of the resource block recursively with their schema paths, meta args and
+// meta nested blocks are skipped
+func (b *ResourceBlock) Walk(visitor *BlockVisitor) error {
+	var err error
+	for _, args := range []*Args{b.RequiredArgs, b.OptionalArgs} {
+		if args == nil || visitor.Arg == nil {
+			continue
+		}
+		for _, arg := range args.Args {
+			if subErr := visitor.Arg(argPath(b.ParentBlockNames, arg.Name), arg); subErr != nil {
+				err = multierror.Append(err, subErr)
+			}
+		}
+	}
+	for _, nbs := range []*NestedBlocks{b.RequiredNestedBlocks, b.OptionalNestedBlocks} {
+		if nbs == nil {
+			continue
+		}
+		for _, nb := range nbs.Blocks {
+			if subErr := nb.Walk(visitor); subErr != nil {
+				err = multierror.Append(err, subErr)
+			}
+		}
+	}
+	return err
+}
+
+func argPath(blockPath []string, name string) []string {
+	return append(append([]string{}, blockPath...), name)
+}
+
 func (b *ResourceBlock) nestedBlocks() []*NestedBlock {
 	var nbs []*NestedBlock
 	for _, nb := range []*NestedBlocks{
@@ -163,7 +201,7 @@ func (b *ResourceBlock) buildNestedBlock(nestedBlock *hclsyntax.Block) *NestedBl
 		nestedBlockName = nestedBlock.Labels[0]
 		sortField = strings.Join(nestedBlock.Labels, "")
 	}
-	parentBlockNames := append(b.ParentBlockNames, nestedBlockName)
+	parentBlockNames := append(append([]string{}, b.ParentBlockNames...), nestedBlockName)
 	if b.Block.Type == "dynamic" && nestedBlockName == "content" {
 		parentBlockNames = b.ParentBlockNames
 	}
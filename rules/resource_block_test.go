@@ -0,0 +1,70 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func Test_ResourceBlockWalk(t *testing.T) {
+	code := `
+resource "azurerm_kubernetes_cluster" "example" {
+  count    = 1
+  name     = "example"
+  location = "eastus"
+
+  default_node_pool {
+    name = "default"
+
+    upgrade_settings {
+      max_surge = "10%"
+    }
+  }
+  dynamic "identity" {
+    for_each = var.identity
+    iterator = id
+    content {
+      type = id.value
+    }
+  }
+
+  lifecycle {
+    ignore_changes = [tags]
+  }
+}`
+	file, diags := hclsyntax.ParseConfig([]byte(code), "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	block := BuildResourceBlock(file.Body.(*hclsyntax.Body).Blocks[0], file, nil)
+	var visited []string
+	err := block.Walk(&BlockVisitor{
+		Arg: func(path []string, arg *Arg) error {
+			visited = append(visited, "arg:"+strings.Join(path, "."))
+			return nil
+		},
+		Block: func(path []string, nb *NestedBlock) error {
+			visited = append(visited, "block:"+strings.Join(path, "."))
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	expected := []string{
+		"arg:resource.azurerm_kubernetes_cluster.name",
+		"arg:resource.azurerm_kubernetes_cluster.location",
+		"block:resource.azurerm_kubernetes_cluster.default_node_pool",
+		"arg:resource.azurerm_kubernetes_cluster.default_node_pool.name",
+		"block:resource.azurerm_kubernetes_cluster.default_node_pool.upgrade_settings",
+		"arg:resource.azurerm_kubernetes_cluster.default_node_pool.upgrade_settings.max_surge",
+		"block:resource.azurerm_kubernetes_cluster.identity",
+		"arg:resource.azurerm_kubernetes_cluster.identity.type",
+	}
+	if diff := cmp.Diff(expected, visited); diff != "" {
+		t.Fatalf("Expected visits are not matched:\n %s\n", diff)
+	}
+}
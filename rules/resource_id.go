@@ -0,0 +1,96 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	resourceGroupARMType = "Microsoft.Resources/resourceGroups"
+	subscriptionARMType  = "Microsoft.Resources/subscriptions"
+)
+
+// armResourceID is a parsed ARM resource ID, e.g.
+// `/subscriptions/<id>/resourceGroups/<name>/providers/Microsoft.Network/virtualNetworks/<name>/subnets/<name>`
+type armResourceID struct {
+	SubscriptionID string
+	ResourceGroup  string
+	// Provider is the namespace of the resource provider, e.g. `Microsoft.Network`
+	Provider string
+	// Types and Names are the types and the names of the resource and its parents, e.g. `virtualNetworks` and `subnets`
+	Types []string
+	Names []string
+}
+
+// looksLikeResourceID checks whether the string is meant to be an ARM resource ID of a subscription or the resources in it
+func looksLikeResourceID(s string) bool {
+	return strings.HasPrefix(strings.ToLower(s), "/subscriptions/")
+}
+
+// parseResourceID parses an ARM resource ID, the keys of the segments are case-insensitive.
+// For extension resources, e.g. `<id>/providers/Microsoft.Insights/diagnosticSettings/<name>`, the extension resource is returned
+func parseResourceID(s string) (*armResourceID, error) {
+	if !strings.HasPrefix(s, "/") {
+		return nil, fmt.Errorf("must start with `/`")
+	}
+	segments := strings.Split(strings.TrimSuffix(s[1:], "/"), "/")
+	if len(segments) < 2 || !strings.EqualFold(segments[0], "subscriptions") {
+		return nil, fmt.Errorf("must start with `/subscriptions/<subscription id>`")
+	}
+	id := &armResourceID{}
+	for i := 0; i < len(segments); i += 2 {
+		key := segments[i]
+		if key == "" {
+			return nil, fmt.Errorf("must not contain empty segments")
+		}
+		if strings.EqualFold(key, "providers") {
+			if i+1 >= len(segments) || segments[i+1] == "" {
+				return nil, fmt.Errorf("`providers` must be followed by a resource provider namespace")
+			}
+			id.Provider, id.Types, id.Names = segments[i+1], nil, nil
+			if i+2 >= len(segments) {
+				return nil, fmt.Errorf("resource provider `%s` must be followed by a resource type and a name", id.Provider)
+			}
+			// `providers/<namespace>` is a pair as well
+			continue
+		}
+		if i+1 >= len(segments) || segments[i+1] == "" {
+			return nil, fmt.Errorf("`%s` must be followed by a name", key)
+		}
+		name := segments[i+1]
+		switch {
+		case i == 0:
+			id.SubscriptionID = name
+		case i == 2 && id.Provider == "" && strings.EqualFold(key, "resourceGroups"):
+			id.ResourceGroup = name
+		case id.Provider == "":
+			return nil, fmt.Errorf("unexpected segment `%s`, expected `resourceGroups` or `providers`", key)
+		default:
+			id.Types = append(id.Types, key)
+			id.Names = append(id.Names, name)
+		}
+	}
+	return id, nil
+}
+
+// Type returns the full ARM resource type, e.g. `Microsoft.Network/virtualNetworks/subnets`
+func (id *armResourceID) Type() string {
+	if id.Provider == "" {
+		if id.ResourceGroup != "" {
+			return resourceGroupARMType
+		}
+		return subscriptionARMType
+	}
+	return id.Provider + "/" + strings.Join(id.Types, "/")
+}
+
+// Name returns the name of the resource
+func (id *armResourceID) Name() string {
+	if id.Provider == "" {
+		if id.ResourceGroup != "" {
+			return id.ResourceGroup
+		}
+		return id.SubscriptionID
+	}
+	return id.Names[len(id.Names)-1]
+}
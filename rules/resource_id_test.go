@@ -0,0 +1,84 @@
+package rules
+
+import (
+	"testing"
+)
+
+func Test_ParseResourceID(t *testing.T) {
+	cases := []struct {
+		ID            string
+		Type          string
+		Name          string
+		ResourceGroup string
+		Error         bool
+	}{
+		{
+			ID:   "/subscriptions/00000000-0000-0000-0000-000000000000",
+			Type: subscriptionARMType,
+			Name: "00000000-0000-0000-0000-000000000000",
+		},
+		{
+			ID:            "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/example",
+			Type:          resourceGroupARMType,
+			Name:          "example",
+			ResourceGroup: "example",
+		},
+		{
+			ID:            "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/snet",
+			Type:          "Microsoft.Network/virtualNetworks/subnets",
+			Name:          "snet",
+			ResourceGroup: "rg",
+		},
+		{
+			ID:            "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/kv/providers/Microsoft.Insights/diagnosticSettings/diag",
+			Type:          "Microsoft.Insights/diagnosticSettings",
+			Name:          "diag",
+			ResourceGroup: "rg",
+		},
+		{
+			ID:    "subscriptions/00000000-0000-0000-0000-000000000000",
+			Error: true,
+		},
+		{
+			ID:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Network",
+			Error: true,
+		},
+		{
+			ID:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks",
+			Error: true,
+		},
+		{
+			ID:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/virtualNetworks/vnet",
+			Error: true,
+		},
+		{
+			ID:    "/subscriptions/00000000-0000-0000-0000-000000000000//resourceGroups/rg",
+			Error: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.ID, func(t *testing.T) {
+			id, err := parseResourceID(tc.ID)
+			if tc.Error {
+				if err == nil {
+					t.Fatalf("Expected error, got type `%s`", id.Type())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			if id.Type() != tc.Type || id.Name() != tc.Name || id.ResourceGroup != tc.ResourceGroup {
+				t.Fatalf("Expected `%s` `%s` in `%s`, got `%s` `%s` in `%s`", tc.Type, tc.Name, tc.ResourceGroup, id.Type(), id.Name(), id.ResourceGroup)
+			}
+		})
+	}
+}
+
+func Test_ARMResourceTypes(t *testing.T) {
+	for resourceType := range armResourceTypes {
+		if queryBlockSchema([]string{"resource", resourceType}) == nil {
+			t.Errorf("resource `%s` is not in the schema", resourceType)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package rules
+
+import "regexp"
+
+// nameCharset is the set of characters allowed in a resource name
+type nameCharset struct {
+	Description string
+	// Char matches a single allowed character
+	Char *regexp.Regexp
+}
+
+var (
+	lowercaseAlphanumerics = nameCharset{
+		Description: "lowercase letters and numbers",
+		Char:        regexp.MustCompile(`^[a-z0-9]$`),
+	}
+	lowercaseAlphanumericsHyphens = nameCharset{
+		Description: "lowercase letters, numbers and hyphens",
+		Char:        regexp.MustCompile(`^[a-z0-9-]$`),
+	}
+	alphanumerics = nameCharset{
+		Description: "letters and numbers",
+		Char:        regexp.MustCompile(`^[a-zA-Z0-9]$`),
+	}
+	alphanumericsHyphens = nameCharset{
+		Description: "letters, numbers and hyphens",
+		Char:        regexp.MustCompile(`^[a-zA-Z0-9-]$`),
+	}
+	alphanumericsPeriodsHyphens = nameCharset{
+		Description: "letters, numbers, periods and hyphens",
+		Char:        regexp.MustCompile(`^[a-zA-Z0-9.-]$`),
+	}
+	alphanumericsUnderscoresHyphens = nameCharset{
+		Description: "letters, numbers, underscores and hyphens",
+		Char:        regexp.MustCompile(`^[a-zA-Z0-9_-]$`),
+	}
+	alphanumericsUnderscoresPeriodsHyphens = nameCharset{
+		Description: "letters, numbers, underscores, periods and hyphens",
+		Char:        regexp.MustCompile(`^[a-zA-Z0-9_.-]$`),
+	}
+	resourceGroupCharset = nameCharset{
+		Description: "letters, numbers, underscores, parentheses, periods and hyphens",
+		Char:        regexp.MustCompile(`^[\p{L}\p{N}_().-]$`),
+	}
+)
+
+// nameConstraint is the naming rule of an argument of a resource type
+type nameConstraint struct {
+	Min     int
+	Max     int
+	Charset nameCharset
+	// Pattern is matched against the names which can be evaluated, e.g. to check the first and the last characters
+	Pattern            *regexp.Regexp
+	PatternDescription string
+}
+
+var (
+	startsAlphanumericEndsAlphanumeric = regexp.MustCompile(`^[a-zA-Z0-9](.*[a-zA-Z0-9])?$`)
+	startsAlphanumericEndsWord         = regexp.MustCompile(`^[a-zA-Z0-9](.*[a-zA-Z0-9_])?$`)
+)
+
+const (
+	startsAlphanumericEndsAlphanumericDescription = "must start and end with a letter or a number"
+	startsAlphanumericEndsWordDescription         = "must start with a letter or a number, and end with a letter, a number or an underscore"
+)
+
+// resourceNameConstraints are the naming rules of the Azure resources, keyed by the azurerm resource type and the argument,
+// as documented in https://learn.microsoft.com/azure/azure-resource-manager/management/resource-name-rules
+var resourceNameConstraints = map[string]map[string]*nameConstraint{
+	"azurerm_api_management": {
+		"name": {Min: 1, Max: 50, Charset: alphanumericsHyphens, Pattern: regexp.MustCompile(`^[a-zA-Z](.*[a-zA-Z0-9])?$`), PatternDescription: "must start with a letter and end with a letter or a number"},
+	},
+	"azurerm_bastion_host": {
+		"name": {Min: 1, Max: 80, Charset: alphanumericsUnderscoresPeriodsHyphens, Pattern: startsAlphanumericEndsWord, PatternDescription: startsAlphanumericEndsWordDescription},
+	},
+	"azurerm_container_registry": {
+		"name": {Min: 5, Max: 50, Charset: alphanumerics},
+	},
+	"azurerm_cosmosdb_account": {
+		"name": {Min: 3, Max: 44, Charset: lowercaseAlphanumericsHyphens, Pattern: regexp.MustCompile(`^[a-z0-9](.*[a-z0-9])?$`), PatternDescription: "must start and end with a lowercase letter or a number"},
+	},
+	"azurerm_data_factory": {
+		"name": {Min: 3, Max: 63, Charset: alphanumericsHyphens, Pattern: startsAlphanumericEndsAlphanumeric, PatternDescription: startsAlphanumericEndsAlphanumericDescription},
+	},
+	"azurerm_eventhub_namespace": {
+		"name": {Min: 6, Max: 50, Charset: alphanumericsHyphens, Pattern: regexp.MustCompile(`^[a-zA-Z](.*[a-zA-Z0-9])?$`), PatternDescription: "must start with a letter and end with a letter or a number"},
+	},
+	"azurerm_firewall": {
+		"name": {Min: 1, Max: 56, Charset: alphanumericsUnderscoresPeriodsHyphens, Pattern: startsAlphanumericEndsWord, PatternDescription: startsAlphanumericEndsWordDescription},
+	},
+	"azurerm_key_vault": {
+		"name": {Min: 3, Max: 24, Charset: alphanumericsHyphens, Pattern: regexp.MustCompile(`^[a-zA-Z]([a-zA-Z0-9]|-[a-zA-Z0-9])*$`), PatternDescription: "must start with a letter, end with a letter or a number, and not contain consecutive hyphens"},
+	},
+	"azurerm_key_vault_key": {
+		"name": {Min: 1, Max: 127, Charset: alphanumericsHyphens},
+	},
+	"azurerm_key_vault_secret": {
+		"name": {Min: 1, Max: 127, Charset: alphanumericsHyphens},
+	},
+	"azurerm_kubernetes_cluster": {
+		"name": {Min: 1, Max: 63, Charset: alphanumericsUnderscoresHyphens, Pattern: startsAlphanumericEndsAlphanumeric, PatternDescription: startsAlphanumericEndsAlphanumericDescription},
+	},
+	"azurerm_linux_function_app": {
+		"name": {Min: 2, Max: 60, Charset: alphanumericsHyphens, Pattern: startsAlphanumericEndsAlphanumeric, PatternDescription: startsAlphanumericEndsAlphanumericDescription},
+	},
+	"azurerm_linux_virtual_machine": {
+		"name":          {Min: 1, Max: 64, Charset: alphanumericsUnderscoresPeriodsHyphens, Pattern: startsAlphanumericEndsWord, PatternDescription: startsAlphanumericEndsWordDescription},
+		"computer_name": {Min: 1, Max: 64, Charset: alphanumericsPeriodsHyphens},
+	},
+	"azurerm_linux_web_app": {
+		"name": {Min: 2, Max: 60, Charset: alphanumericsHyphens, Pattern: startsAlphanumericEndsAlphanumeric, PatternDescription: startsAlphanumericEndsAlphanumericDescription},
+	},
+	"azurerm_log_analytics_workspace": {
+		"name": {Min: 4, Max: 63, Charset: alphanumericsHyphens, Pattern: startsAlphanumericEndsAlphanumeric, PatternDescription: startsAlphanumericEndsAlphanumericDescription},
+	},
+	"azurerm_mssql_server": {
+		"name": {Min: 1, Max: 63, Charset: lowercaseAlphanumericsHyphens, Pattern: regexp.MustCompile(`^[a-z0-9](.*[a-z0-9])?$`), PatternDescription: "must start and end with a lowercase letter or a number"},
+	},
+	"azurerm_mysql_flexible_server": {
+		"name": {Min: 3, Max: 63, Charset: lowercaseAlphanumericsHyphens, Pattern: regexp.MustCompile(`^[a-z0-9](.*[a-z0-9])?$`), PatternDescription: "must start and end with a lowercase letter or a number"},
+	},
+	"azurerm_network_interface": {
+		"name": {Min: 1, Max: 80, Charset: alphanumericsUnderscoresPeriodsHyphens, Pattern: startsAlphanumericEndsWord, PatternDescription: startsAlphanumericEndsWordDescription},
+	},
+	"azurerm_network_security_group": {
+		"name": {Min: 1, Max: 80, Charset: alphanumericsUnderscoresPeriodsHyphens, Pattern: startsAlphanumericEndsWord, PatternDescription: startsAlphanumericEndsWordDescription},
+	},
+	"azurerm_postgresql_flexible_server": {
+		"name": {Min: 3, Max: 63, Charset: lowercaseAlphanumericsHyphens, Pattern: regexp.MustCompile(`^[a-z0-9](.*[a-z0-9])?$`), PatternDescription: "must start and end with a lowercase letter or a number"},
+	},
+	"azurerm_public_ip": {
+		"name": {Min: 1, Max: 80, Charset: alphanumericsUnderscoresPeriodsHyphens, Pattern: startsAlphanumericEndsWord, PatternDescription: startsAlphanumericEndsWordDescription},
+	},
+	"azurerm_redis_cache": {
+		"name": {Min: 1, Max: 63, Charset: alphanumericsHyphens, Pattern: regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9]|-[a-zA-Z0-9])*$`), PatternDescription: "must start and end with a letter or a number, and not contain consecutive hyphens"},
+	},
+	"azurerm_resource_group": {
+		"name": {Min: 1, Max: 90, Charset: resourceGroupCharset, Pattern: regexp.MustCompile(`[^.]$`), PatternDescription: "must not end with a period"},
+	},
+	"azurerm_route_table": {
+		"name": {Min: 1, Max: 80, Charset: alphanumericsUnderscoresPeriodsHyphens, Pattern: startsAlphanumericEndsWord, PatternDescription: startsAlphanumericEndsWordDescription},
+	},
+	"azurerm_search_service": {
+		"name": {Min: 2, Max: 60, Charset: lowercaseAlphanumericsHyphens, Pattern: regexp.MustCompile(`^[a-z0-9]([a-z0-9]|-[a-z0-9])*$`), PatternDescription: "must start and end with a lowercase letter or a number, and not contain consecutive hyphens"},
+	},
+	"azurerm_service_plan": {
+		"name": {Min: 1, Max: 60, Charset: alphanumericsHyphens},
+	},
+	"azurerm_servicebus_namespace": {
+		"name": {Min: 6, Max: 50, Charset: alphanumericsHyphens, Pattern: regexp.MustCompile(`^[a-zA-Z](.*[a-zA-Z0-9])?$`), PatternDescription: "must start with a letter and end with a letter or a number"},
+	},
+	"azurerm_storage_account": {
+		"name": {Min: 3, Max: 24, Charset: lowercaseAlphanumerics},
+	},
+	"azurerm_storage_container": {
+		"name": {Min: 3, Max: 63, Charset: lowercaseAlphanumericsHyphens, Pattern: regexp.MustCompile(`^[a-z0-9]([a-z0-9]|-[a-z0-9])*$`), PatternDescription: "must start and end with a lowercase letter or a number, and not contain consecutive hyphens"},
+	},
+	"azurerm_storage_queue": {
+		"name": {Min: 3, Max: 63, Charset: lowercaseAlphanumericsHyphens, Pattern: regexp.MustCompile(`^[a-z0-9]([a-z0-9]|-[a-z0-9])*$`), PatternDescription: "must start and end with a lowercase letter or a number, and not contain consecutive hyphens"},
+	},
+	"azurerm_storage_share": {
+		"name": {Min: 3, Max: 63, Charset: lowercaseAlphanumericsHyphens, Pattern: regexp.MustCompile(`^[a-z0-9]([a-z0-9]|-[a-z0-9])*$`), PatternDescription: "must start and end with a lowercase letter or a number, and not contain consecutive hyphens"},
+	},
+	"azurerm_storage_table": {
+		"name": {Min: 3, Max: 63, Charset: alphanumerics, Pattern: regexp.MustCompile(`^[a-zA-Z]`), PatternDescription: "must start with a letter"},
+	},
+	"azurerm_subnet": {
+		"name": {Min: 1, Max: 80, Charset: alphanumericsUnderscoresPeriodsHyphens, Pattern: startsAlphanumericEndsWord, PatternDescription: startsAlphanumericEndsWordDescription},
+	},
+	"azurerm_user_assigned_identity": {
+		"name": {Min: 3, Max: 128, Charset: alphanumericsUnderscoresHyphens, Pattern: regexp.MustCompile(`^[a-zA-Z0-9]`), PatternDescription: "must start with a letter or a number"},
+	},
+	"azurerm_virtual_network": {
+		"name": {Min: 2, Max: 64, Charset: alphanumericsUnderscoresPeriodsHyphens, Pattern: startsAlphanumericEndsWord, PatternDescription: startsAlphanumericEndsWordDescription},
+	},
+	"azurerm_windows_function_app": {
+		"name": {Min: 2, Max: 60, Charset: alphanumericsHyphens, Pattern: startsAlphanumericEndsAlphanumeric, PatternDescription: startsAlphanumericEndsAlphanumericDescription},
+	},
+	// the name is the computer name if `computer_name` is not set, see computerNameFallbacks
+	"azurerm_windows_virtual_machine": {
+		"name":          {Min: 1, Max: 64, Charset: alphanumericsUnderscoresPeriodsHyphens, Pattern: startsAlphanumericEndsWord, PatternDescription: startsAlphanumericEndsWordDescription},
+		"computer_name": {Min: 1, Max: 15, Charset: alphanumericsHyphens, Pattern: regexp.MustCompile(`[^0-9]`), PatternDescription: "must not consist of numbers only"},
+	},
+	"azurerm_windows_virtual_machine_scale_set": {
+		"computer_name_prefix": {Min: 1, Max: 9, Charset: alphanumericsHyphens},
+	},
+	"azurerm_windows_web_app": {
+		"name": {Min: 2, Max: 60, Charset: alphanumericsHyphens, Pattern: startsAlphanumericEndsAlphanumeric, PatternDescription: startsAlphanumericEndsAlphanumericDescription},
+	},
+}
+
+// computerNameFallbacks lists the resource types whose `name` is used as the computer name, or its prefix,
+// if the argument is not set, in which case `name` must satisfy the constraint of that argument as well
+var computerNameFallbacks = map[string]string{
+	"azurerm_windows_virtual_machine":           "computer_name",
+	"azurerm_windows_virtual_machine_scale_set": "computer_name_prefix",
+}
@@ -3,6 +3,25 @@ package rules
 import "github.com/terraform-linters/tflint-plugin-sdk/tflint"
 
 var Rules = []tflint.Rule{
+	NewAzurermAllowedLocationRule(),
 	NewAzurermArgOrderRule(),
+	NewAzurermArgumentTypeRule(),
+	NewAzurermAttributeReferenceRule(),
+	NewAzurermComputedOnlyArgumentRule(),
+	NewAzurermDeprecatedArgumentRule(),
+	NewAzurermHardcodedResourceIDRule(),
+	NewAzurermHardcodedSensitiveArgumentRule(),
+	NewAzurermLocationRule(),
+	NewAzurermMissingRequiredArgumentRule(),
+	NewAzurermNamingConventionRule(),
+	NewAzurermNestedBlockCardinalityRule(),
+	NewAzurermProviderBlockRule(),
+	NewAzurermProviderFunctionRule(),
+	NewAzurermResourceIDFormatRule(),
+	NewAzurermResourceIDParsingRule(),
+	NewAzurermResourceIDReferenceRule(),
+	NewAzurermResourceNameRule(),
 	NewAzurermResourceTagRule(),
+	NewAzurermSchemaVersionRule(),
+	NewAzurermUnknownArgumentRule(),
 }
@@ -0,0 +1,57 @@
+package rules
+
+import (
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.RuleSet = new(RuleSet)
+
+// RuleSet is the azurerm-ext ruleset, which selects the azurerm schema for every module before checking
+type RuleSet struct {
+	tflint.BuiltinRuleSet
+}
+
+// ruleSetConfig is the config in the `plugin "azurerm-ext"` block
+type ruleSetConfig struct {
+	// SchemaFile is the path of a `terraform providers schema -json` output, which replaces the bundled schemas
+	SchemaFile string `hclext:"schema_file,optional"`
+}
+
+// ConfigSchema returns the schema of the plugin config
+func (r *RuleSet) ConfigSchema() *hclext.BodySchema {
+	return hclext.ImpliedBodySchema(&ruleSetConfig{})
+}
+
+// ApplyConfig loads the schema file if configured
+func (r *RuleSet) ApplyConfig(content *hclext.BodyContent) error {
+	config := ruleSetConfig{}
+	if diags := hclext.DecodeBody(content, nil, &config); diags.HasErrors() {
+		return diags
+	}
+	customSchema = nil
+	if config.SchemaFile == "" {
+		return nil
+	}
+	s, err := loadSchemaFile(config.SchemaFile)
+	if err != nil {
+		return err
+	}
+	customSchema = s
+	return nil
+}
+
+// NewRunner selects the bundled azurerm schema matching the azurerm version used by the module. If no bundled schema is
+// close to that version, the checks based on the schema are skipped rather than reporting false results
+func (r *RuleSet) NewRunner(runner tflint.Runner) (tflint.Runner, error) {
+	resolution, err := resolveAzurermSchema(runner)
+	if err != nil {
+		return nil, err
+	}
+	if !resolution.Close {
+		useAzurermSchema(nil)
+		return runner, nil
+	}
+	useAzurermSchema(resolution.Schema)
+	return runner, nil
+}
@@ -0,0 +1,170 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+const testSchemaFile = `{
+  "format_version": "1.0",
+  "provider_schemas": {
+    "registry.terraform.io/hashicorp/azurerm": {
+      "provider": {"version": 0, "block": {"attributes": {"subscription_id": {"type": "string", "optional": true}}}},
+      "resource_schemas": {
+        "azurerm_fork_only": {
+          "version": 0,
+          "block": {
+            "attributes": {
+              "name": {"type": "string", "required": true},
+              "tags": {"type": ["map", "string"], "optional": true}
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+func Test_RuleSet_SchemaFile(t *testing.T) {
+	t.Cleanup(func() {
+		customSchema = nil
+		useAzurermSchema(bundledSchemas[0])
+	})
+	path := writeTestFile(t, "schema.json", testSchemaFile)
+	ruleSet := &RuleSet{}
+	if err := ruleSet.ApplyConfig(pluginConfig(t, ruleSet, `schema_file = "`+filepath.ToSlash(path)+`"`)); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	runner := helper.TestRunner(t, map[string]string{"config.tf": `resource "azurerm_fork_only" "example" {}`})
+	if _, err := ruleSet.NewRunner(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if queryBlockSchema([]string{"resource", "azurerm_fork_only"}) == nil {
+		t.Fatal("Expected resource schema loaded from schema file")
+	}
+	if queryBlockSchema([]string{"resource", "azurerm_resource_group"}) != nil {
+		t.Fatal("Expected bundled schema replaced by schema file")
+	}
+}
+
+func Test_RuleSet_NoCloseSchema(t *testing.T) {
+	t.Cleanup(func() {
+		useAzurermSchema(bundledSchemas[0])
+	})
+	runner := helper.TestRunner(t, map[string]string{"config.tf": `
+terraform {
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 3.0"
+    }
+  }
+}
+
+resource "azurerm_storage_account" "example" {
+  enable_https_traffic_only = true
+}`})
+	if _, err := (&RuleSet{}).NewRunner(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if queryBlockSchema([]string{"resource", "azurerm_storage_account"}) != nil {
+		t.Fatal("Expected no schema when no bundled schema is close to the azurerm version")
+	}
+	if err := NewAzurermUnknownArgumentRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	AssertIssues(t, helper.Issues{}, runner.Issues)
+}
+
+func Test_RuleSet_InvalidConstraint(t *testing.T) {
+	t.Cleanup(func() {
+		useAzurermSchema(bundledSchemas[0])
+	})
+	runner := helper.TestRunner(t, map[string]string{"config.tf": `
+terraform {
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> four"
+    }
+  }
+}`})
+	if _, err := (&RuleSet{}).NewRunner(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if queryBlockSchema([]string{"resource", "azurerm_storage_account"}) == nil {
+		t.Fatal("Expected the latest bundled schema for an invalid constraint")
+	}
+}
+
+func Test_LoadSchemaFile_Invalid(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected string
+	}{
+		{
+			Name:     "malformed json",
+			Content:  `{"format_version": "1.0", "provider_schemas": {`,
+			Expected: "malformed azurerm schema file",
+		},
+		{
+			Name:     "unsupported format version",
+			Content:  `{"format_version": "2.0", "provider_schemas": {}}`,
+			Expected: "unsupported provider schema format version",
+		},
+		{
+			Name:     "no azurerm provider",
+			Content:  `{"format_version": "1.0", "provider_schemas": {"registry.terraform.io/hashicorp/azuread": {}}}`,
+			Expected: "has no azurerm provider schema, found: registry.terraform.io/hashicorp/azuread",
+		},
+		{
+			Name:     "no resource schemas",
+			Content:  `{"format_version": "1.0", "provider_schemas": {"registry.terraform.io/hashicorp/azurerm": {}}}`,
+			Expected: "has no resource schemas",
+		},
+		{
+			Name:     "resource without block",
+			Content:  `{"format_version": "1.0", "provider_schemas": {"registry.terraform.io/hashicorp/azurerm": {"resource_schemas": {"azurerm_broken": {"version": 0}}}}}`,
+			Expected: "resource `azurerm_broken` has no block schema",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := loadSchemaFile(writeTestFile(t, "schema.json", tc.Content))
+			if err == nil || !strings.Contains(err.Error(), tc.Expected) {
+				t.Fatalf("Expected error containing %q, got %v", tc.Expected, err)
+			}
+		})
+	}
+	if _, err := loadSchemaFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatal("Expected error for missing schema file")
+	}
+}
+
+func writeTestFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func pluginConfig(t *testing.T, ruleSet *RuleSet, src string) *hclext.BodyContent {
+	file, diags := hclsyntax.ParseConfig([]byte(src), ".tflint.hcl", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	content, diags := hclext.Content(file.Body, ruleSet.ConfigSchema())
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	return content
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/rules"
+	"github.com/hashicorp/go-version"
+)
+
+// bundle is the JSON of an azurerm schema to be bundled
+type bundle struct {
+	version string
+	src     []byte
+}
+
+func newBundle(schemaFile, v string) (*bundle, error) {
+	parsed, err := version.NewVersion(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid azurerm version `%s`: %+v", v, err)
+	}
+	s, err := rules.ReadSchemaFile(schemaFile)
+	if err != nil {
+		return nil, err
+	}
+	// maps are marshalled with sorted keys, so the same schema always gets the same JSON
+	src, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return &bundle{version: parsed.String(), src: src}, nil
+}
+
+func (b *bundle) filename(dir string) string {
+	return filepath.Join(dir, fmt.Sprintf("azurerm-%s.json.gz", b.version))
+}
+
+// write gzips the schema without timestamp, so the output is deterministic
+func (b *bundle) write(dir string) (string, error) {
+	buf := &bytes.Buffer{}
+	w, err := gzip.NewWriterLevel(buf, gzip.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err = w.Write(b.src); err != nil {
+		return "", err
+	}
+	if err = w.Close(); err != nil {
+		return "", err
+	}
+	output := b.filename(dir)
+	return output, os.WriteFile(output, buf.Bytes(), 0600)
+}
+
+// verify compares the decompressed JSON rather than the gzip data, which may differ between Go versions
+func (b *bundle) verify(dir string) error {
+	filename := b.filename(dir)
+	f, err := os.Open(filepath.Clean(filename))
+	if os.IsNotExist(err) {
+		return fmt.Errorf("azurerm v%s schema is not bundled, %s is missing", b.version, filename)
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("invalid bundled schema %s: %+v", filename, err)
+	}
+	bundled, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("invalid bundled schema %s: %+v", filename, err)
+	}
+	if bytes.Equal(bundled, b.src) {
+		return nil
+	}
+	changes, err := diffEntries(bundled, b.src)
+	if err != nil {
+		return fmt.Errorf("invalid bundled schema %s: %+v", filename, err)
+	}
+	msg := fmt.Sprintf("bundled schema %s is stale", filename)
+	for _, c := range changes {
+		msg += "\n  " + c
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// schemaEntries is a provider schema JSON whose entries are kept undecoded for comparison
+type schemaEntries struct {
+	ConfigSchema             json.RawMessage            `json:"provider"`
+	ResourceSchemas          map[string]json.RawMessage `json:"resource_schemas"`
+	DataSourceSchemas        map[string]json.RawMessage `json:"data_source_schemas"`
+	EphemeralResourceSchemas map[string]json.RawMessage `json:"ephemeral_resource_schemas"`
+	Functions                map[string]json.RawMessage `json:"functions"`
+}
+
+// diffEntries lists the provider configuration, resources, data sources, ephemeral resources and functions which are
+// added, removed or changed from the bundled schema JSON to the generated one
+func diffEntries(bundled, generated []byte) ([]string, error) {
+	var old, cur schemaEntries
+	if err := json.Unmarshal(bundled, &old); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(generated, &cur); err != nil {
+		return nil, err
+	}
+	var changes []string
+	if !bytes.Equal(old.ConfigSchema, cur.ConfigSchema) {
+		changes = append(changes, "provider configuration changed")
+	}
+	changes = append(changes, diffEntryMap("resource", old.ResourceSchemas, cur.ResourceSchemas)...)
+	changes = append(changes, diffEntryMap("data source", old.DataSourceSchemas, cur.DataSourceSchemas)...)
+	changes = append(changes, diffEntryMap("ephemeral resource", old.EphemeralResourceSchemas, cur.EphemeralResourceSchemas)...)
+	changes = append(changes, diffEntryMap("function", old.Functions, cur.Functions)...)
+	return changes, nil
+}
+
+func diffEntryMap(kind string, old, cur map[string]json.RawMessage) []string {
+	var names []string
+	for name := range old {
+		names = append(names, name)
+	}
+	for name := range cur {
+		if _, ok := old[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var changes []string
+	for _, name := range names {
+		o, inOld := old[name]
+		c, inCur := cur[name]
+		switch {
+		case !inOld:
+			changes = append(changes, fmt.Sprintf("%s `%s` added", kind, name))
+		case !inCur:
+			changes = append(changes, fmt.Sprintf("%s `%s` removed", kind, name))
+		case !bytes.Equal(o, c):
+			changes = append(changes, fmt.Sprintf("%s `%s` changed", kind, name))
+		}
+	}
+	return changes
+}
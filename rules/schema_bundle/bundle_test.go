@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSchema = `{
+  "format_version": "1.0",
+  "provider_schemas": {
+    "registry.terraform.io/hashicorp/azurerm": {
+      "provider": {
+        "version": 0,
+        "block": {"attributes": {"subscription_id": {"type": "string", "optional": true}}}
+      },
+      "resource_schemas": {
+        "azurerm_resource_group": {
+          "version": 0,
+          "block": {"attributes": {"name": {"type": "string", "required": true}, "location": {"type": "string", "required": true}}}
+        },
+        "azurerm_subnet": {
+          "version": 0,
+          "block": {"attributes": {"name": {"type": "string", "required": true}}}
+        }
+      }
+    }
+  }
+}`
+
+func Test_Bundle(t *testing.T) {
+	dir := t.TempDir()
+	b := testBundle(t, dir, testSchema)
+	output, err := b.write(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output != filepath.Join(dir, "azurerm-4.31.0.json.gz") {
+		t.Fatalf("unexpected output %s", output)
+	}
+	written, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = b.write(dir); err != nil {
+		t.Fatal(err)
+	}
+	rewritten, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(written, rewritten) {
+		t.Fatal("Expected the same output for the same schema")
+	}
+	if err = b.verify(dir); err != nil {
+		t.Fatalf("Expected the bundled schema up to date, got %+v", err)
+	}
+}
+
+func Test_Bundle_VerifyStale(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := testBundle(t, dir, testSchema).write(dir); err != nil {
+		t.Fatal(err)
+	}
+	updated := strings.Replace(testSchema, `"azurerm_subnet": {
+          "version": 0,
+          "block": {"attributes": {"name": {"type": "string", "required": true}}}
+        }`, `"azurerm_virtual_network": {
+          "version": 0,
+          "block": {"attributes": {"name": {"type": "string", "required": true}}}
+        }`, 1)
+	updated = strings.Replace(updated, `"location": {"type": "string", "required": true}`, `"location": {"type": "string", "optional": true}`, 1)
+	err := testBundle(t, dir, updated).verify(dir)
+	if err == nil {
+		t.Fatal("Expected stale bundled schema")
+	}
+	expected := "bundled schema " + filepath.Join(dir, "azurerm-4.31.0.json.gz") + ` is stale
+  resource ` + "`azurerm_resource_group`" + ` changed
+  resource ` + "`azurerm_subnet`" + ` removed
+  resource ` + "`azurerm_virtual_network`" + ` added`
+	if err.Error() != expected {
+		t.Fatalf("Expected %s, got %s", expected, err.Error())
+	}
+}
+
+func Test_Bundle_VerifyMissing(t *testing.T) {
+	dir := t.TempDir()
+	err := testBundle(t, dir, testSchema).verify(dir)
+	if err == nil || !strings.Contains(err.Error(), "azurerm v4.31.0 schema is not bundled") {
+		t.Fatalf("Expected missing bundled schema, got %+v", err)
+	}
+}
+
+func Test_Bundle_InvalidVersion(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(filename, []byte(testSchema), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newBundle(filename, "latest"); err == nil || !strings.Contains(err.Error(), "invalid azurerm version `latest`") {
+		t.Fatalf("Expected invalid version error, got %+v", err)
+	}
+}
+
+func testBundle(t *testing.T, dir, schema string) *bundle {
+	filename := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(filename, []byte(schema), 0600); err != nil {
+		t.Fatal(err)
+	}
+	b, err := newBundle(filename, "v4.31.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// schema_bundle writes the azurerm schema in a `terraform providers schema -json` output file into the bundled schemas
+// of the rules, as a gzipped provider schema JSON named after the azurerm version. With -verify it writes nothing and
+// fails if the bundled schema of the version is missing or differs from the schema file
+func main() {
+	schemaFile := flag.String("schema", "", "`terraform providers schema -json` output of a module using the azurerm provider")
+	v := flag.String("version", "", "azurerm version of the schema file, e.g. 4.31.0")
+	outputDir := flag.String("o", filepath.Join("rules", "schemas"), "directory of the bundled schemas")
+	verify := flag.Bool("verify", false, "fail if the bundled schema is stale instead of writing it")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: schema_bundle -schema <file> -version <version> [-o <dir>] [-verify]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *schemaFile == "" || *v == "" || flag.NArg() > 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	b, err := newBundle(*schemaFile, *v)
+	if err != nil {
+		exit(err)
+	}
+	if *verify {
+		if err = b.verify(*outputDir); err != nil {
+			exit(err)
+		}
+		return
+	}
+	output, err := b.write(*outputDir)
+	if err != nil {
+		exit(err)
+	}
+	fmt.Println(output)
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err.Error())
+	os.Exit(1)
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/rules"
+	"github.com/agext/levenshtein"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Change kinds
+const (
+	Removed         = "removed"
+	Renamed         = "renamed"
+	NewlyRequired   = "newly_required"
+	NewlyDeprecated = "newly_deprecated"
+)
+
+// Change is a change between two azurerm schemas which affects the configuration
+type Change struct {
+	Kind        string `json:"kind"`
+	Path        string `json:"path"`
+	Replacement string `json:"replacement,omitempty"`
+	Filename    string `json:"filename"`
+	Line        int    `json:"line"`
+}
+
+func (c Change) String() string {
+	location := fmt.Sprintf("%s:%d", c.Filename, c.Line)
+	switch c.Kind {
+	case Renamed:
+		return fmt.Sprintf("%s: `%s` is renamed to `%s`", location, c.Path, c.Replacement)
+	case NewlyRequired:
+		return fmt.Sprintf("%s: `%s` is newly required", location, c.Path)
+	case NewlyDeprecated:
+		return fmt.Sprintf("%s: `%s` is newly deprecated", location, c.Path)
+	default:
+		return fmt.Sprintf("%s: `%s` is removed", location, c.Path)
+	}
+}
+
+type differ struct {
+	from    rules.BlockSchemaLookup
+	to      rules.BlockSchemaLookup
+	changes []Change
+}
+
+// diffConfig reports the changes between two azurerm schemas which affect the azurerm blocks in the configuration directory
+func diffConfig(dir string, from, to rules.BlockSchemaLookup) ([]Change, error) {
+	filenames, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+	d := &differ{from: from, to: to}
+	for _, filename := range filenames {
+		if subErr := d.diffFile(filename); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	sort.SliceStable(d.changes, func(i, j int) bool {
+		if d.changes[i].Filename != d.changes[j].Filename {
+			return d.changes[i].Filename < d.changes[j].Filename
+		}
+		if d.changes[i].Line != d.changes[j].Line {
+			return d.changes[i].Line < d.changes[j].Line
+		}
+		return d.changes[i].Path < d.changes[j].Path
+	})
+	return d.changes, err
+}
+
+func (d *differ) diffFile(filename string) error {
+	src, err := os.ReadFile(filepath.Clean(filename))
+	if err != nil {
+		return err
+	}
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return diags
+	}
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		if !isAzurermBlock(block) {
+			continue
+		}
+		d.diffBlock(block, file)
+	}
+	return nil
+}
+
+func isAzurermBlock(block *hclsyntax.Block) bool {
+	switch block.Type {
+	case "provider":
+		return len(block.Labels) == 1 && block.Labels[0] == "azurerm"
+	case "resource", "data", "ephemeral":
+		return len(block.Labels) == 2 && strings.HasPrefix(block.Labels[0], "azurerm_")
+	}
+	return false
+}
+
+func (d *differ) diffBlock(block *hclsyntax.Block, file *hcl.File) {
+	rootPath := []string{block.Type, block.Labels[0]}
+	fromRoot, toRoot := d.from(rootPath), d.to(rootPath)
+	if fromRoot == nil {
+		return
+	}
+	if toRoot == nil {
+		d.add(Removed, rootPath, "", block.DefRange())
+		return
+	}
+	if toRoot.Deprecated && !fromRoot.Deprecated {
+		d.add(NewlyDeprecated, rootPath, "", block.DefRange())
+	}
+	d.diffRequired(rootPath, block.Body, block.DefRange())
+	b := rules.BuildResourceBlock(block, file, nil)
+	_ = b.Walk(&rules.BlockVisitor{
+		Arg: func(path []string, arg *rules.Arg) error {
+			d.diffArg(path, arg.Range)
+			return nil
+		},
+		Block: func(path []string, nb *rules.NestedBlock) error {
+			d.diffNestedBlock(path, nb)
+			return nil
+		},
+	})
+}
+
+func (d *differ) diffArg(path []string, r hcl.Range) {
+	parent, name := path[:len(path)-1], path[len(path)-1]
+	fromParent, toParent := d.from(parent), d.to(parent)
+	if fromParent == nil || toParent == nil {
+		return
+	}
+	fromAttr, ok := fromParent.Attributes[name]
+	if !ok {
+		return
+	}
+	toAttr, ok := toParent.Attributes[name]
+	if !ok {
+		d.addRemovedOrRenamed(path, attributeNames(fromParent), attributeNames(toParent), r)
+		return
+	}
+	if toAttr.Deprecated && !fromAttr.Deprecated {
+		d.add(NewlyDeprecated, path, "", r)
+	}
+}
+
+func (d *differ) diffNestedBlock(path []string, nb *rules.NestedBlock) {
+	parent, name := path[:len(path)-1], path[len(path)-1]
+	fromParent, toParent := d.from(parent), d.to(parent)
+	if fromParent == nil || toParent == nil {
+		return
+	}
+	fromBlock, ok := fromParent.NestedBlocks[name]
+	if !ok {
+		return
+	}
+	toBlock, ok := toParent.NestedBlocks[name]
+	if !ok {
+		d.addRemovedOrRenamed(path, blockNames(fromParent), blockNames(toParent), nb.DefRange())
+		return
+	}
+	if toBlock.Block.Deprecated && !fromBlock.Block.Deprecated {
+		d.add(NewlyDeprecated, path, "", nb.DefRange())
+	}
+	body := nb.Block.Body
+	if nb.Block.Type == "dynamic" {
+		content := contentBlock(nb.Block)
+		if content == nil {
+			return
+		}
+		body = content.Body
+	}
+	d.diffRequired(path, body, nb.DefRange())
+}
+
+// diffRequired reports the args and nested blocks which are required in the new schema only, and not set in the body
+func (d *differ) diffRequired(path []string, body *hclsyntax.Body, r hcl.Range) {
+	fromBlock, toBlock := d.from(path), d.to(path)
+	if fromBlock == nil || toBlock == nil {
+		return
+	}
+	for name, attr := range toBlock.Attributes {
+		if !attr.Required {
+			continue
+		}
+		if old, ok := fromBlock.Attributes[name]; ok && old.Required {
+			continue
+		}
+		if _, ok := body.Attributes[name]; ok {
+			continue
+		}
+		d.add(NewlyRequired, append(append([]string{}, path...), name), "", r)
+	}
+	for name, nb := range toBlock.NestedBlocks {
+		if nb.MinItems == 0 {
+			continue
+		}
+		if old, ok := fromBlock.NestedBlocks[name]; ok && old.MinItems > 0 {
+			continue
+		}
+		if hasNestedBlock(body, name) {
+			continue
+		}
+		d.add(NewlyRequired, append(append([]string{}, path...), name), "", r)
+	}
+}
+
+func (d *differ) addRemovedOrRenamed(path []string, fromNames, toNames map[string]bool, r hcl.Range) {
+	if replacement, ok := renamedTo(path[len(path)-1], fromNames, toNames); ok {
+		d.add(Renamed, path, replacement, r)
+		return
+	}
+	d.add(Removed, path, "", r)
+}
+
+func (d *differ) add(kind string, path []string, replacement string, r hcl.Range) {
+	d.changes = append(d.changes, Change{
+		Kind:        kind,
+		Path:        strings.Join(path, "."),
+		Replacement: replacement,
+		Filename:    r.Filename,
+		Line:        r.Start.Line,
+	})
+}
+
+// renamedTo guesses the new name of a removed name among the names only in the new schema,
+// e.g. `enable_https_traffic_only` is renamed to `https_traffic_only_enabled`
+func renamedTo(name string, fromNames, toNames map[string]bool) (string, bool) {
+	var candidates []string
+	for candidate := range toNames {
+		if fromNames[candidate] {
+			continue
+		}
+		if nameTokens(candidate) == nameTokens(name) || levenshtein.Distance(candidate, name, nil) <= 2 {
+			candidates = append(candidates, candidate)
+		}
+	}
+	if len(candidates) != 1 {
+		return "", false
+	}
+	return candidates[0], true
+}
+
+var ignoredNameTokens = map[string]bool{"enable": true, "enabled": true, "is": true}
+
+func nameTokens(name string) string {
+	var tokens []string
+	for _, token := range strings.Split(name, "_") {
+		if !ignoredNameTokens[token] {
+			tokens = append(tokens, token)
+		}
+	}
+	sort.Strings(tokens)
+	return strings.Join(tokens, "_")
+}
+
+func attributeNames(block *tfjson.SchemaBlock) map[string]bool {
+	names := make(map[string]bool)
+	for name := range block.Attributes {
+		names[name] = true
+	}
+	return names
+}
+
+func blockNames(block *tfjson.SchemaBlock) map[string]bool {
+	names := make(map[string]bool)
+	for name := range block.NestedBlocks {
+		names[name] = true
+	}
+	return names
+}
+
+func hasNestedBlock(body *hclsyntax.Body, name string) bool {
+	for _, b := range body.Blocks {
+		if b.Type == name || b.Type == "dynamic" && len(b.Labels) == 1 && b.Labels[0] == name {
+			return true
+		}
+	}
+	return false
+}
+
+func contentBlock(dynamic *hclsyntax.Block) *hclsyntax.Block {
+	for _, b := range dynamic.Body.Blocks {
+		if b.Type == "content" {
+			return b
+		}
+	}
+	return nil
+}
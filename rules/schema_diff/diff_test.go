@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/rules"
+	"github.com/google/go-cmp/cmp"
+)
+
+const fromSchema = `{
+  "format_version": "1.0",
+  "provider_schemas": {
+    "registry.terraform.io/hashicorp/azurerm": {
+      "resource_schemas": {
+        "azurerm_storage_account": {
+          "version": 0,
+          "block": {
+            "attributes": {
+              "name": {"type": "string", "required": true},
+              "enable_https_traffic_only": {"type": "bool", "optional": true},
+              "allow_blob_public_access": {"type": "bool", "optional": true},
+              "min_tls_version": {"type": "string", "optional": true}
+            },
+            "block_types": {
+              "network_rules": {
+                "nesting_mode": "list",
+                "block": {
+                  "attributes": {
+                    "default_action": {"type": "string", "optional": true},
+                    "bypass": {"type": ["set", "string"], "optional": true}
+                  }
+                }
+              }
+            }
+          }
+        },
+        "azurerm_app_service": {
+          "version": 0,
+          "block": {"attributes": {"name": {"type": "string", "required": true}}}
+        }
+      }
+    }
+  }
+}`
+
+const toSchema = `{
+  "format_version": "1.0",
+  "provider_schemas": {
+    "registry.terraform.io/hashicorp/azurerm": {
+      "resource_schemas": {
+        "azurerm_storage_account": {
+          "version": 0,
+          "block": {
+            "attributes": {
+              "name": {"type": "string", "required": true},
+              "https_traffic_only_enabled": {"type": "bool", "optional": true},
+              "min_tls_version": {"type": "string", "optional": true, "deprecated": true},
+              "account_tier": {"type": "string", "required": true}
+            },
+            "block_types": {
+              "network_rules": {
+                "nesting_mode": "list",
+                "block": {
+                  "attributes": {
+                    "default_action": {"type": "string", "required": true},
+                    "bypass": {"type": ["set", "string"], "optional": true}
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+const config = `
+resource "azurerm_storage_account" "example" {
+  name                      = "example"
+  enable_https_traffic_only = true
+  allow_blob_public_access  = false
+  min_tls_version           = "TLS1_2"
+
+  dynamic "network_rules" {
+    for_each = var.network_rules
+    content {
+      bypass = network_rules.value
+    }
+  }
+}
+
+resource "azurerm_app_service" "example" {
+  name = "example"
+}
+
+resource "random_string" "example" {
+  length = 6
+}
+`
+
+func Test_DiffConfig(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"from.json": fromSchema,
+		"to.json":   toSchema,
+		"main.tf":   config,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	from, err := rules.LoadSchema(filepath.Join(dir, "from.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := rules.LoadSchema(filepath.Join(dir, "to.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes, err := diffConfig(dir, from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(dir, "main.tf")
+	expected := []Change{
+		{Kind: NewlyRequired, Path: "resource.azurerm_storage_account.account_tier", Filename: filename, Line: 2},
+		{Kind: Renamed, Path: "resource.azurerm_storage_account.enable_https_traffic_only", Replacement: "https_traffic_only_enabled", Filename: filename, Line: 4},
+		{Kind: Removed, Path: "resource.azurerm_storage_account.allow_blob_public_access", Filename: filename, Line: 5},
+		{Kind: NewlyDeprecated, Path: "resource.azurerm_storage_account.min_tls_version", Filename: filename, Line: 6},
+		{Kind: NewlyRequired, Path: "resource.azurerm_storage_account.network_rules.default_action", Filename: filename, Line: 8},
+		{Kind: Removed, Path: "resource.azurerm_app_service", Filename: filename, Line: 16},
+	}
+	if diff := cmp.Diff(expected, changes); diff != "" {
+		t.Fatalf("Expected changes are not matched:\n %s\n", diff)
+	}
+}
+
+func Test_LoadSchema_UnknownVersion(t *testing.T) {
+	if _, err := rules.LoadSchema("1.0.0"); err == nil {
+		t.Fatal("Expected error for version which is not bundled")
+	}
+	if _, err := rules.LoadSchema("4.31.0"); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+}
+
+func Test_Run_SchemaFileToBundledVersion(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"azurerm-3.116.0.json": fromSchema,
+		"main.tf":              config,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-from", filepath.Join(dir, "azurerm-3.116.0.json"), "-to", "4.31.0", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	expected := fmt.Sprintf("%s:4: `resource.azurerm_storage_account.enable_https_traffic_only` is renamed to `https_traffic_only_enabled`", filepath.Join(dir, "main.tf"))
+	if !strings.Contains(stdout.String(), expected+"\n") {
+		t.Fatalf("Expected output containing %q, got:\n%s", expected, stdout.String())
+	}
+}
+
+func Test_Run_UnbundledVersion(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-from", "3.116.0", "-to", "4.31.0", t.TempDir()}, &stdout, &stderr); code != 1 {
+		t.Fatalf("Expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "terraform providers schema -json") {
+		t.Fatalf("Expected error pointing to schema files, got: %s", stderr.String())
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Azure/tflint-ruleset-azurerm-ext/rules"
+)
+
+// schema_diff reports the resources and arguments used in a configuration directory which are removed, renamed,
+// newly required or newly deprecated between two azurerm schemas, each schema is a bundled azurerm version or
+// a `terraform providers schema -json` output file. Only the versions of the schemas bundled in the plugin can be
+// given as versions, the schema of any other version, e.g. the azurerm version in use before an upgrade, is a file
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs schema_diff with the command line arguments and returns the exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("schema_diff", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	from := flags.String("from", "", "current azurerm version or schema file")
+	to := flags.String("to", "", "target azurerm version or schema file")
+	format := flags.String("format", "text", "output format, text or json")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: schema_diff -from <version|file> -to <version|file> [-format text|json] [dir]\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *from == "" || *to == "" || (*format != "text" && *format != "json") || flags.NArg() > 1 {
+		flags.Usage()
+		return 2
+	}
+	dir := "."
+	if flags.NArg() == 1 {
+		dir = flags.Arg(0)
+	}
+	fromSchema, err := rules.LoadSchema(*from)
+	if err != nil {
+		return exit(stderr, err)
+	}
+	toSchema, err := rules.LoadSchema(*to)
+	if err != nil {
+		return exit(stderr, err)
+	}
+	changes, err := diffConfig(dir, fromSchema, toSchema)
+	if err != nil {
+		return exit(stderr, err)
+	}
+	if *format == "json" {
+		if changes == nil {
+			changes = []Change{}
+		}
+		out, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return exit(stderr, err)
+		}
+		fmt.Fprintln(stdout, string(out))
+		return 0
+	}
+	for _, c := range changes {
+		fmt.Fprintln(stdout, c.String())
+	}
+	return 0
+}
+
+func exit(stderr io.Writer, err error) int {
+	fmt.Fprintln(stderr, err.Error())
+	return 1
+}
@@ -0,0 +1,208 @@
+package rules
+
+import (
+	"compress/gzip"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-version"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// bundledSchemaDir contains the bundled azurerm schemas, each file is a gzipped provider schema JSON named after its version
+const bundledSchemaDir = "schemas"
+
+//go:embed schemas/*.json.gz
+var bundledSchemaFiles embed.FS
+
+// rootSchemaFunc returns the schema of a resource/data source/ephemeral resource by its kind and type name,
+// or the azurerm provider configuration by `provider` and `azurerm`, nil if not found
+type rootSchemaFunc func(kind, name string) *tfjson.Schema
+
+// functionsFunc returns the provider-defined functions keyed by name
+type functionsFunc func() map[string]*tfjson.FunctionSignature
+
+// schemaIndex is an index of block schemas keyed by the full block path, e.g. `resource.azurerm_kubernetes_cluster.default_node_pool`.
+// The blocks of a resource/data source are indexed when it's queried for the first time
+type schemaIndex struct {
+	mu      sync.Mutex
+	roots   rootSchemaFunc
+	blocks  map[string]*tfjson.SchemaBlock
+	indexed map[string]bool
+	key     []byte
+}
+
+func newSchemaIndex(roots rootSchemaFunc) *schemaIndex {
+	return &schemaIndex{
+		roots:   roots,
+		blocks:  make(map[string]*tfjson.SchemaBlock),
+		indexed: make(map[string]bool),
+	}
+}
+
+// block returns the block schema of the path, the first two elements of the path are the kind and the type name
+func (i *schemaIndex) block(path []string) *tfjson.SchemaBlock {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	// the key is built in a reused buffer, since map lookups by string(bytes) don't allocate
+	i.key = append(append(append(i.key[:0], path[0]...), '.'), path[1]...)
+	if !i.indexed[string(i.key)] {
+		rootKey := string(i.key)
+		i.indexed[rootKey] = true
+		if s := i.roots(path[0], path[1]); s != nil && s.Block != nil {
+			i.add(rootKey, s.Block)
+		}
+	}
+	for _, name := range path[2:] {
+		i.key = append(append(i.key, '.'), name...)
+	}
+	return i.blocks[string(i.key)]
+}
+
+func (i *schemaIndex) add(key string, block *tfjson.SchemaBlock) {
+	i.blocks[key] = block
+	for name, nb := range block.NestedBlocks {
+		if nb.Block != nil {
+			i.add(key+"."+name, nb.Block)
+		}
+	}
+}
+
+// providerSchemaRoots looks up the resources/data sources in a decoded provider schema
+func providerSchemaRoots(s *tfjson.ProviderSchema) rootSchemaFunc {
+	return func(kind, name string) *tfjson.Schema {
+		switch kind {
+		case "provider":
+			if name == "azurerm" {
+				return s.ConfigSchema
+			}
+		case "resource":
+			return s.ResourceSchemas[name]
+		case "data":
+			return s.DataSourceSchemas[name]
+		case "ephemeral":
+			return s.EphemeralResourceSchemas[name]
+		}
+		return nil
+	}
+}
+
+// providerSchemaFunctions returns the functions in a decoded provider schema
+func providerSchemaFunctions(s *tfjson.ProviderSchema) functionsFunc {
+	return func() map[string]*tfjson.FunctionSignature {
+		return s.Functions
+	}
+}
+
+// rawProviderSchema is a provider schema whose resources/data sources/ephemeral resources are decoded on demand
+type rawProviderSchema struct {
+	ConfigSchema             json.RawMessage                      `json:"provider,omitempty"`
+	ResourceSchemas          map[string]json.RawMessage           `json:"resource_schemas,omitempty"`
+	DataSourceSchemas        map[string]json.RawMessage           `json:"data_source_schemas,omitempty"`
+	EphemeralResourceSchemas map[string]json.RawMessage           `json:"ephemeral_resource_schemas,omitempty"`
+	Functions                map[string]*tfjson.FunctionSignature `json:"functions,omitempty"`
+}
+
+// bundledSchema decodes a bundled schema file when it's queried for the first time
+type bundledSchema struct {
+	once sync.Once
+	name string
+	raw  *rawProviderSchema
+}
+
+func (b *bundledSchema) load() *rawProviderSchema {
+	b.once.Do(func() {
+		b.raw = &rawProviderSchema{}
+		src, err := readBundledSchema(b.name)
+		if err == nil {
+			err = json.Unmarshal(src, b.raw)
+		}
+		if err != nil {
+			panic(fmt.Sprintf("invalid bundled schema %s: %+v", b.name, err))
+		}
+	})
+	return b.raw
+}
+
+func (b *bundledSchema) functions() map[string]*tfjson.FunctionSignature {
+	return b.load().Functions
+}
+
+// roots decodes every resource/data source/ephemeral resource/provider configuration schema when it's queried
+func (b *bundledSchema) roots(kind, typeName string) *tfjson.Schema {
+	raw := b.load()
+	var src json.RawMessage
+	switch kind {
+	case "provider":
+		if typeName == "azurerm" {
+			src = raw.ConfigSchema
+		}
+	case "resource":
+		src = raw.ResourceSchemas[typeName]
+	case "data":
+		src = raw.DataSourceSchemas[typeName]
+	case "ephemeral":
+		src = raw.EphemeralResourceSchemas[typeName]
+	}
+	if src == nil {
+		return nil
+	}
+	s := &tfjson.Schema{}
+	if err := json.Unmarshal(src, s); err != nil {
+		panic(fmt.Sprintf("invalid bundled schema %s of %s `%s`: %+v", b.name, kind, typeName, err))
+	}
+	return s
+}
+
+func readBundledSchema(name string) ([]byte, error) {
+	f, err := bundledSchemaFiles.Open(path.Join(bundledSchemaDir, name))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+// loadBundledSchemas lists the bundled schema files without decoding them, the latest version comes first
+func loadBundledSchemas() []*azurermSchema {
+	entries, err := bundledSchemaFiles.ReadDir(bundledSchemaDir)
+	if err != nil {
+		panic(err.Error())
+	}
+	var schemas []*azurermSchema
+	for _, entry := range entries {
+		v, ok := bundledSchemaVersion(entry.Name())
+		if !ok {
+			continue
+		}
+		b := &bundledSchema{name: entry.Name()}
+		schemas = append(schemas, newAzurermSchema(v, fmt.Sprintf("bundled azurerm v%s schema", v), b.roots, b.functions))
+	}
+	if len(schemas) == 0 {
+		panic("no bundled azurerm schema")
+	}
+	sort.Slice(schemas, func(i, j int) bool {
+		return schemas[i].Version.GreaterThan(schemas[j].Version)
+	})
+	return schemas
+}
+
+func bundledSchemaVersion(name string) (*version.Version, bool) {
+	if !strings.HasPrefix(name, "azurerm-") || !strings.HasSuffix(name, ".json.gz") {
+		return nil, false
+	}
+	v, err := version.NewVersion(strings.TrimSuffix(strings.TrimPrefix(name, "azurerm-"), ".json.gz"))
+	return v, err == nil
+}
@@ -0,0 +1,178 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+const benchmarkConfig = `
+resource "azurerm_kubernetes_cluster" "example" {
+  location            = azurerm_resource_group.example.location
+  name                = "example-aks1"
+  resource_group_name = azurerm_resource_group.example.name
+  dns_prefix          = "exampleaks1"
+  tags = {
+    Environment = "Production"
+  }
+
+  default_node_pool {
+    name       = "default"
+    node_count = 1
+    vm_size    = "Standard_D2_v2"
+
+    upgrade_settings {
+      max_surge = "10%"
+    }
+  }
+  identity {
+    type = "SystemAssigned"
+  }
+  network_profile {
+    network_plugin = "azure"
+
+    load_balancer_profile {
+      managed_outbound_ip_count = 2
+    }
+  }
+}
+
+resource "azurerm_storage_account" "example" {
+  account_replication_type = "GRS"
+  account_tier             = "Standard"
+  location                 = azurerm_resource_group.example.location
+  name                     = "storageaccountname"
+  resource_group_name      = azurerm_resource_group.example.name
+
+  blob_properties {
+    delete_retention_policy {
+      days = 7
+    }
+  }
+}
+`
+
+func Test_SchemaIndex(t *testing.T) {
+	s := loadBundledSchemas()[0]
+	cases := []struct {
+		Path     []string
+		Expected bool
+	}{
+		{Path: []string{"resource", "azurerm_kubernetes_cluster"}, Expected: true},
+		{Path: []string{"resource", "azurerm_kubernetes_cluster", "default_node_pool", "upgrade_settings"}, Expected: true},
+		{Path: []string{"resource", "azurerm_kubernetes_cluster", "upgrade_settings"}, Expected: false},
+		{Path: []string{"data", "azurerm_kubernetes_cluster", "timeouts"}, Expected: true},
+		{Path: []string{"resource", "azurerm_not_exist"}, Expected: false},
+		{Path: []string{"ephemeral", "azurerm_key_vault_secret"}, Expected: true},
+		{Path: []string{"provider", "azurerm", "features", "key_vault"}, Expected: true},
+		{Path: []string{"provider", "azurerm", "features", "network"}, Expected: false},
+	}
+	for _, tc := range cases {
+		if actual := s.index.block(tc.Path) != nil; actual != tc.Expected {
+			t.Fatalf("Expected block schema of %v found: %t, got %t", tc.Path, tc.Expected, actual)
+		}
+	}
+	if len(s.index.indexed) != 5 {
+		t.Fatalf("Expected only queried resources/data sources indexed, got %v", s.index.indexed)
+	}
+	nodePool := s.index.block([]string{"resource", "azurerm_kubernetes_cluster", "default_node_pool"})
+	if _, ok := nodePool.Attributes["vm_size"]; !ok {
+		t.Fatal("Expected `vm_size` in `default_node_pool` schema")
+	}
+	if f := s.functions()["parse_resource_id"]; f == nil || len(f.Parameters) != 1 {
+		t.Fatal("Expected signature of `parse_resource_id`")
+	}
+}
+
+// BenchmarkLoadBundledSchema measures the plugin startup cost with the lazily decoded bundled schema
+func BenchmarkLoadBundledSchema(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		s := loadBundledSchemas()[0]
+		s.index.block([]string{"resource", "azurerm_kubernetes_cluster"})
+	}
+}
+
+// BenchmarkDecodeAllSchemas measures the plugin startup cost when every schema is decoded eagerly,
+// which is what importing the terraform-azurerm-schema generated package costs
+func BenchmarkDecodeAllSchemas(b *testing.B) {
+	src := bundledSchemaSource(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := &tfjson.ProviderSchema{}
+		if err := json.Unmarshal(src, s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkQueryBlockSchema measures the lookup of nested block schemas through the index
+func BenchmarkQueryBlockSchema(b *testing.B) {
+	s := loadBundledSchemas()[0]
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, path := range benchmarkPaths {
+			s.index.block(path)
+		}
+	}
+}
+
+// BenchmarkWalkBlockSchema measures the lookup of nested block schemas by walking from the resource schema
+func BenchmarkWalkBlockSchema(b *testing.B) {
+	s := &tfjson.ProviderSchema{}
+	if err := json.Unmarshal(bundledSchemaSource(b), s); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, path := range benchmarkPaths {
+			walkBlockSchema(s.ResourceSchemas, path)
+		}
+	}
+}
+
+// BenchmarkAzurermArgOrderRule measures the check time of a file
+func BenchmarkAzurermArgOrderRule(b *testing.B) {
+	rule := NewAzurermArgOrderRule()
+	runner := helper.TestRunner(&testing.T{}, map[string]string{"config.tf": benchmarkConfig})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := rule.Check(runner); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+var benchmarkPaths = [][]string{
+	{"resource", "azurerm_kubernetes_cluster"},
+	{"resource", "azurerm_kubernetes_cluster", "default_node_pool"},
+	{"resource", "azurerm_kubernetes_cluster", "default_node_pool", "upgrade_settings"},
+	{"resource", "azurerm_kubernetes_cluster", "network_profile", "load_balancer_profile"},
+	{"resource", "azurerm_storage_account", "blob_properties", "delete_retention_policy"},
+}
+
+func walkBlockSchema(root map[string]*tfjson.Schema, path []string) *tfjson.SchemaBlock {
+	b, ok := root[path[1]]
+	if !ok {
+		return nil
+	}
+	r := b.Block
+	for i := 2; i < len(path); i++ {
+		nb, ok := r.NestedBlocks[path[i]]
+		if !ok {
+			return nil
+		}
+		r = nb.Block
+	}
+	return r
+}
+
+func bundledSchemaSource(b *testing.B) []byte {
+	src, err := readBundledSchema(fmt.Sprintf("azurerm-%s.json.gz", bundledSchemas[0].Version))
+	if err != nil {
+		b.Fatal(err)
+	}
+	return src
+}
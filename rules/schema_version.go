@@ -0,0 +1,278 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const lockFileName = ".terraform.lock.hcl"
+
+// maxSchemaMinorDistance is the max number of minor versions between a locked azurerm version and a bundled schema
+// close to it. azurerm adds resources and arguments in most minor versions, so a farther schema gives false results
+const maxSchemaMinorDistance = 5
+
+var azurermProviderSources = []string{
+	"hashicorp/azurerm",
+	"registry.terraform.io/hashicorp/azurerm",
+}
+
+// schemaResolution is the result of matching the azurerm version used by a module with the bundled schemas
+type schemaResolution struct {
+	// Schema is the schema the rules are checked against
+	Schema *azurermSchema
+	// Requested is the azurerm version or version constraint found in the module, empty if none is found
+	Requested string
+	// Range is where the requested version is declared
+	Range hcl.Range
+	// Locked is true if the requested version is locked in `.terraform.lock.hcl`, false if it's a constraint
+	Locked bool
+	// Close is false if no bundled schema is close to the requested version, i.e. has the same major version and at most
+	// maxSchemaMinorDistance minor versions in between for a locked version, or satisfies the constraint
+	Close bool
+	// Ignored explains why a declared azurerm version is ignored, e.g. a malformed constraint, empty if none is
+	Ignored string
+	// IgnoredRange is where the ignored version is declared
+	IgnoredRange hcl.Range
+}
+
+// resolveAzurermSchema selects the bundled schema matching the azurerm version locked in `.terraform.lock.hcl`,
+// or the `required_providers` constraint if the module has no lock file. The schema loaded from the `schema_file`
+// plugin config is always selected if configured. An invalid lock file or constraint is ignored, and the latest
+// bundled schema is selected if no other version is found, as if the module declared none
+func resolveAzurermSchema(runner tflint.Runner) (*schemaResolution, error) {
+	if customSchema != nil {
+		return &schemaResolution{Schema: customSchema, Close: true}, nil
+	}
+	files, err := runner.GetFiles()
+	if err != nil {
+		return nil, err
+	}
+	ignored, ignoredRange := "", hcl.Range{}
+	if locked, r, ok, err := lockedAzurermVersion(moduleDir(files)); err != nil {
+		ignored, ignoredRange = err.Error(), r
+	} else if ok {
+		resolution := selectSchemaByVersion(bundledSchemas, locked)
+		resolution.Range, resolution.Locked = r, true
+		return resolution, nil
+	}
+	resolution := &schemaResolution{Schema: bundledSchemas[0], Close: true}
+	if constraint, r, ok := azurermVersionConstraint(files); ok {
+		if selected, err := selectSchemaByConstraint(bundledSchemas, constraint); err != nil {
+			if ignored == "" {
+				ignored, ignoredRange = err.Error(), r
+			}
+		} else {
+			resolution = selected
+			resolution.Range = r
+		}
+	}
+	resolution.Ignored, resolution.IgnoredRange = ignored, ignoredRange
+	return resolution, nil
+}
+
+func moduleDir(files map[string]*hcl.File) string {
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return "."
+	}
+	sort.Strings(names)
+	return filepath.Dir(names[0])
+}
+
+// lockedAzurermVersion reads the azurerm version from the lock file in the given directory
+func lockedAzurermVersion(dir string) (string, hcl.Range, bool, error) {
+	filename := filepath.Join(dir, lockFileName)
+	src, err := os.ReadFile(filepath.Clean(filename))
+	if os.IsNotExist(err) {
+		return "", hcl.Range{}, false, nil
+	}
+	if err != nil {
+		return "", hcl.Range{Filename: filename, Start: hcl.InitialPos, End: hcl.InitialPos}, false, fmt.Errorf("cannot read %s: %+v", lockFileName, err)
+	}
+	return parseLockFile(src, filename)
+}
+
+func parseLockFile(src []byte, filename string) (string, hcl.Range, bool, error) {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		r := hcl.Range{Filename: filename, Start: hcl.InitialPos, End: hcl.InitialPos}
+		if diags[0].Subject != nil {
+			r = *diags[0].Subject
+		}
+		return "", r, false, fmt.Errorf("malformed %s: %s", lockFileName, diags[0].Summary)
+	}
+	body := file.Body.(*hclsyntax.Body)
+	for _, block := range body.Blocks {
+		if block.Type != "provider" || len(block.Labels) != 1 || !isAzurermSource(block.Labels[0]) {
+			continue
+		}
+		attr, ok := block.Body.Attributes["version"]
+		if !ok {
+			continue
+		}
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() || val.Type() != cty.String || val.IsNull() {
+			return "", attr.SrcRange, false, fmt.Errorf("invalid azurerm version in %s", lockFileName)
+		}
+		return val.AsString(), attr.SrcRange, true, nil
+	}
+	return "", hcl.Range{}, false, nil
+}
+
+// azurermVersionConstraint reads the azurerm version constraint from the `required_providers` blocks
+func azurermVersionConstraint(files map[string]*hcl.File) (string, hcl.Range, bool) {
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		body, ok := files[name].Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type != "terraform" {
+				continue
+			}
+			for _, nb := range block.Body.Blocks {
+				if nb.Type != "required_providers" {
+					continue
+				}
+				for providerName, attr := range nb.Body.Attributes {
+					if constraint, ok := requiredAzurermVersion(providerName, attr); ok {
+						return constraint, attr.SrcRange, true
+					}
+				}
+			}
+		}
+	}
+	return "", hcl.Range{}, false
+}
+
+func requiredAzurermVersion(providerName string, attr *hclsyntax.Attribute) (string, bool) {
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || val.IsNull() || !val.IsWhollyKnown() {
+		return "", false
+	}
+	// legacy syntax, e.g. `azurerm = "~> 3.0"`
+	if val.Type() == cty.String {
+		return val.AsString(), providerName == "azurerm"
+	}
+	if !val.Type().IsObjectType() {
+		return "", false
+	}
+	source := ""
+	if val.Type().HasAttribute("source") && val.GetAttr("source").Type() == cty.String {
+		source = val.GetAttr("source").AsString()
+	}
+	if source == "" && providerName != "azurerm" || source != "" && !isAzurermSource(source) {
+		return "", false
+	}
+	if !val.Type().HasAttribute("version") || val.GetAttr("version").Type() != cty.String {
+		return "", false
+	}
+	return val.GetAttr("version").AsString(), true
+}
+
+func isAzurermSource(source string) bool {
+	source = strings.ToLower(source)
+	for _, s := range azurermProviderSources {
+		if source == s {
+			return true
+		}
+	}
+	return false
+}
+
+// selectSchemaByVersion selects the latest schema not newer than the given version with the same major version,
+// or the oldest newer one if there is no such schema. It's close if at most maxSchemaMinorDistance minor versions
+// are in between
+func selectSchemaByVersion(schemas []*azurermSchema, v string) *schemaResolution {
+	resolution := &schemaResolution{Schema: schemas[0], Requested: v}
+	wanted, err := version.NewVersion(v)
+	if err != nil {
+		return resolution
+	}
+	var candidate *azurermSchema
+	for _, s := range schemas {
+		if s.Version.Segments()[0] != wanted.Segments()[0] {
+			continue
+		}
+		candidate = s
+		if s.Version.LessThanOrEqual(wanted) {
+			break
+		}
+	}
+	if candidate == nil {
+		return resolution
+	}
+	resolution.Schema = candidate
+	distance := candidate.Version.Segments()[1] - wanted.Segments()[1]
+	resolution.Close = distance <= maxSchemaMinorDistance && distance >= -maxSchemaMinorDistance
+	return resolution
+}
+
+// selectSchemaByConstraint selects the latest schema satisfying the given constraint
+func selectSchemaByConstraint(schemas []*azurermSchema, c string) (*schemaResolution, error) {
+	constraints, err := version.NewConstraint(c)
+	if err != nil {
+		return nil, fmt.Errorf("invalid azurerm version constraint `%s`: %+v", c, err)
+	}
+	for _, s := range schemas {
+		if constraints.Check(s.Version) {
+			return &schemaResolution{Schema: s, Requested: c, Close: true}, nil
+		}
+	}
+	return &schemaResolution{Schema: schemas[0], Requested: c}, nil
+}
+
+// sinceMajorVersion checks whether the module certainly uses an azurerm version since the given major version, i.e.
+// the locked version is, or the constraint rules out the older versions. Nothing is certain if the module declares
+// no version or no bundled schema is close to it. A schema file is generated from the azurerm version in use, which is
+// assumed to be a recent one if the schema file doesn't tell
+func (r *schemaResolution) sinceMajorVersion(major int) bool {
+	if customSchema != nil && r.Schema == customSchema {
+		return r.Schema.Version == nil || r.Schema.Version.Segments()[0] >= major
+	}
+	if !r.Close || r.Requested == "" {
+		return false
+	}
+	if r.Locked {
+		v, err := version.NewVersion(r.Requested)
+		return err == nil && v.Segments()[0] >= major
+	}
+	return constraintExcludesOlderVersions(r.Requested, major)
+}
+
+// constraintExcludesOlderVersions checks whether the version constraint has a lower bound since the given major
+// version, e.g. `>= 4.0` or `~> 4.1, != 4.2.0` for v4
+func constraintExcludesOlderVersions(c string, major int) bool {
+	for _, single := range strings.Split(c, ",") {
+		single = strings.TrimSpace(single)
+		op := single[:len(single)-len(strings.TrimLeft(single, "=<>!~"))]
+		v, err := version.NewVersion(strings.TrimSpace(single[len(op):]))
+		if err != nil {
+			continue
+		}
+		switch op {
+		case "", "=", ">", ">=", "~>":
+			if v.Segments()[0] >= major {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -1,2 +0,0 @@
-cd ./terraform-provider-azurerm
-git pull origin main